@@ -1,13 +1,31 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/internal/output"
 )
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(errors.ExitCodeError)
+		printError(os.Stderr, err)
+		os.Exit(errors.ExitCode(err))
 	}
 }
+
+// printError writes err to w: "Error: ..." for humans, or {"error": {...}} with -o json/ndjson.
+// API errors keep their status, endpoint and message as separate JSON fields.
+func printError(w io.Writer, err error) {
+	if OutputJSON() {
+		var payload interface{} = map[string]string{"message": err.Error()}
+		if apiErr, ok := errors.AsAPIError(err); ok {
+			payload = apiErr
+		}
+		_ = output.PrintJSON(w, map[string]interface{}{"error": payload})
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Error: %v\n", err)
+}
@@ -1,60 +1,122 @@
 package main
 
 import (
-	"os"
-	"path/filepath"
-
 	"github.com/angolovin/yougile-cli/internal/cmd"
+	"github.com/angolovin/yougile-cli/internal/config"
+	"github.com/angolovin/yougile-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
-const configDir = "yougile-cli"
-const configFile = "config.yaml"
-
 var (
-	configPath string
-	outputJSON bool
+	configPath   string
+	profileName  string
+	outputFormat string
+	outputJSON   bool
+	columns      string
+	sortBy       string
+	noHeaders    bool
+	wrapCells    bool
+	rawIDs       bool
+	noCache      bool
 )
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to config file (default: ~/.config/yougile-cli/config.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to config file (env YOUGILE_CONFIG, default: ~/.config/yougile-cli/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "config profile to use (env YOUGILE_PROFILE, default: current_profile from config)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", string(output.FormatTable), "output format: table|wide|json|yaml|csv|tsv|ndjson|template=...|jsonpath=...")
 	rootCmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "output as JSON")
+	_ = rootCmd.PersistentFlags().MarkDeprecated("json", "use -o json")
+	rootCmd.PersistentFlags().StringVar(&columns, "columns", "", "comma-separated table columns: headers or row fields, e.g. id,title,assigned,deadline")
+	rootCmd.PersistentFlags().StringVar(&sortBy, "sort-by", "", "sort rows by a column or row field; prefix with - for descending")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "omit table and csv/tsv headers")
+	rootCmd.PersistentFlags().BoolVar(&wrapCells, "wrap", false, "wrap long table cells instead of truncating them to the terminal width")
+	rootCmd.PersistentFlags().BoolVar(&rawIDs, "raw-ids", false, "show IDs instead of resolving users, columns, boards, projects and stickers to names")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "fetch lists used for names from the API instead of the local cache")
 
-	rootCmd.AddCommand(cmd.NewConfigCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewAuthCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewCompanyCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewUsersCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewProjectsCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewBoardsCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewColumnsCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewTasksCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewDepartmentsCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewWebhooksCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewFilesCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewChatsCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewStickersCmd(ResolveConfigPath, OutputJSON))
-	rootCmd.AddCommand(cmd.NewCrmCmd(ResolveConfigPath, OutputJSON))
+	g := &cmd.Globals{
+		ResolvePath: ResolveConfigPath,
+		Profile:     Profile,
+		Output:      Output,
+		View:        View,
+		Layout:      Layout,
+		RawIDs:      RawIDs,
+		NoCache:     NoCache,
+	}
+
+	rootCmd.AddCommand(cmd.NewConfigCmd(g))
+	rootCmd.AddCommand(cmd.NewAuthCmd(g))
+	rootCmd.AddCommand(cmd.NewCompanyCmd(g))
+	rootCmd.AddCommand(cmd.NewUsersCmd(g))
+	rootCmd.AddCommand(cmd.NewProjectsCmd(g))
+	rootCmd.AddCommand(cmd.NewBoardsCmd(g))
+	rootCmd.AddCommand(cmd.NewColumnsCmd(g))
+	rootCmd.AddCommand(cmd.NewTasksCmd(g))
+	rootCmd.AddCommand(cmd.NewDepartmentsCmd(g))
+	rootCmd.AddCommand(cmd.NewWebhooksCmd(g))
+	rootCmd.AddCommand(cmd.NewFilesCmd(g))
+	rootCmd.AddCommand(cmd.NewChatsCmd(g))
+	rootCmd.AddCommand(cmd.NewStickersCmd(g))
+	rootCmd.AddCommand(cmd.NewCrmCmd(g))
+	rootCmd.AddCommand(cmd.NewReportsCmd(g))
+	rootCmd.AddCommand(cmd.NewCacheCmd(g))
 }
 
 var rootCmd = &cobra.Command{
 	Use:   "yougile",
 	Short: "YouGile CLI — project management and CRM",
 	Long:  "CLI for YouGile: tasks, projects, boards, users, and more.",
+	// main prints errors itself (human or --json); usage is only shown for flag errors.
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		_, err := output.ParseFormat(Output())
+		return err
+	},
 }
 
-// ResolveConfigPath returns the config file path: flag value if set, otherwise default under user config dir.
+// ResolveConfigPath returns the config file path: flag value if set, then
+// $YOUGILE_CONFIG, otherwise default under user config dir.
 func ResolveConfigPath() (string, error) {
-	if configPath != "" {
-		return configPath, nil
-	}
-	dir, err := os.UserConfigDir()
-	if err != nil {
-		return "", err
+	path, _, err := config.ResolvePath(configPath)
+	return path, err
+}
+
+// Profile returns the --profile value.
+func Profile() string {
+	return profileName
+}
+
+// Output returns the -o/--output format; the deprecated --json means json.
+func Output() string {
+	if outputJSON && outputFormat == string(output.FormatTable) {
+		return string(output.FormatJSON)
 	}
-	return filepath.Join(dir, configDir, configFile), nil
+	return outputFormat
+}
+
+// View returns the --columns and --sort-by values.
+func View() output.View {
+	return output.View{Columns: output.ParseColumns(columns), SortBy: sortBy}
+}
+
+// Layout returns the --no-headers and --wrap values.
+func Layout() output.TableOptions {
+	return output.TableOptions{NoHeaders: noHeaders, Wrap: wrapCells}
+}
+
+// RawIDs returns the --raw-ids value.
+func RawIDs() bool {
+	return rawIDs
+}
+
+// NoCache returns the --no-cache value.
+func NoCache() bool {
+	return noCache
 }
 
-// OutputJSON returns whether --json was set.
+// OutputJSON reports whether errors should be printed as JSON
+// (-o json or -o ndjson).
 func OutputJSON() bool {
-	return outputJSON
+	f := output.Format(Output())
+	return f == output.FormatJSON || f == output.FormatNDJSON
 }
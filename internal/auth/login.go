@@ -6,51 +6,125 @@ import (
 	"net/http"
 	"strings"
 
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
 	"github.com/angolovin/yougile-cli/pkg/client"
 )
 
-// Login obtains an API key using email and password.
-// It calls GetCompanies to resolve company ID (uses first company if multiple), then creates an auth key.
-// baseURL is the YouGile API base (e.g. https://ru.yougile.com).
-func Login(ctx context.Context, baseURL, email, password string) (apiKey string, err error) {
-	baseURL = strings.TrimRight(baseURL, "/")
-	api, err := client.NewClientWithResponses(baseURL)
-	if err != nil {
-		return "", fmt.Errorf("create client: %w", err)
+// CompanySelector picks the company to create the key for from the
+// companies the account belongs to (never empty).
+type CompanySelector func(companies []client.CompanyListDtoBase) (client.CompanyListDtoBase, error)
+
+// MultipleCompaniesError is returned by ChooseCompany when the account belongs
+// to several companies and none was asked for.
+type MultipleCompaniesError struct {
+	Companies []client.CompanyListDtoBase
+}
+
+func (e *MultipleCompaniesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "account belongs to %d companies:", len(e.Companies))
+	for _, c := range e.Companies {
+		fmt.Fprintf(&b, "\n  %s  %s", c.Id, c.Name)
+		if c.IsAdmin {
+			b.WriteString(" (admin)")
+		}
+	}
+	return b.String()
+}
+
+// ChooseCompany returns the company with the given id, or else the one whose
+// name matches name (case-insensitive). With neither set, it returns the only
+// company or a *MultipleCompaniesError.
+func ChooseCompany(companies []client.CompanyListDtoBase, id, name string) (client.CompanyListDtoBase, error) {
+	switch {
+	case id != "":
+		for _, c := range companies {
+			if c.Id == id {
+				return c, nil
+			}
+		}
+		return client.CompanyListDtoBase{}, fmt.Errorf("company %s not found for this account", id)
+	case name != "":
+		var found []client.CompanyListDtoBase
+		for _, c := range companies {
+			if strings.EqualFold(c.Name, name) {
+				found = append(found, c)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], nil
+		}
+		if len(found) > 1 {
+			return client.CompanyListDtoBase{}, fmt.Errorf("company name %q is ambiguous: %w", name, &MultipleCompaniesError{Companies: found})
+		}
+		return client.CompanyListDtoBase{}, fmt.Errorf("company %q not found for this account", name)
+	case len(companies) == 1:
+		return companies[0], nil
+	default:
+		return client.CompanyListDtoBase{}, &MultipleCompaniesError{Companies: companies}
 	}
+}
 
-	companiesResp, err := api.GetCompaniesWithResponse(ctx, nil, client.GetCompaniesJSONRequestBody{
+// Companies lists the companies the account belongs to.
+func Companies(ctx context.Context, api client.ClientWithResponsesInterface, email, password string) ([]client.CompanyListDtoBase, error) {
+	limit := float32(1000)
+	resp, err := api.GetCompaniesWithResponse(ctx, &client.GetCompaniesParams{Limit: &limit}, client.GetCompaniesJSONRequestBody{
 		Login:    email,
 		Password: password,
 	})
 	if err != nil {
-		return "", fmt.Errorf("get companies: %w", err)
+		return nil, fmt.Errorf("get companies: %w", err)
 	}
-
-	if companiesResp.HTTPResponse.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("get companies: HTTP %s", companiesResp.HTTPResponse.Status)
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, clierrors.FromResponse("get companies", resp.HTTPResponse, resp.Body)
 	}
-	if companiesResp.JSON200 == nil || len(companiesResp.JSON200.Content) == 0 {
-		return "", fmt.Errorf("no companies found for this account")
+	if resp.JSON200 == nil || len(resp.JSON200.Content) == 0 {
+		return nil, fmt.Errorf("no companies found for this account")
+	}
+	return resp.JSON200.Content, nil
+}
+
+// Login obtains an API key using email and password.
+// It lists the account's companies, lets choose pick one (nil means
+// ChooseCompany with no filter), then creates an auth key for it.
+// baseURL is the YouGile API base (e.g. https://ru.yougile.com).
+// opts are passed to the API client (e.g. client.WithHTTPClient).
+func Login(ctx context.Context, baseURL, email, password string, choose CompanySelector, opts ...client.ClientOption) (apiKey string, company client.CompanyListDtoBase, err error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	api, err := client.NewClientWithResponses(baseURL, opts...)
+	if err != nil {
+		return "", company, fmt.Errorf("create client: %w", err)
 	}
 
-	companyID := companiesResp.JSON200.Content[0].Id
+	companies, err := Companies(ctx, api, email, password)
+	if err != nil {
+		return "", company, err
+	}
+	if choose == nil {
+		choose = func(cs []client.CompanyListDtoBase) (client.CompanyListDtoBase, error) {
+			return ChooseCompany(cs, "", "")
+		}
+	}
+	company, err = choose(companies)
+	if err != nil {
+		return "", company, err
+	}
 
 	createResp, err := api.AuthKeyControllerCreateWithResponse(ctx, client.AuthKeyControllerCreateJSONRequestBody{
 		Login:     email,
 		Password:  password,
-		CompanyId: companyID,
+		CompanyId: company.Id,
 	})
 	if err != nil {
-		return "", fmt.Errorf("create key: %w", err)
+		return "", company, fmt.Errorf("create key: %w", err)
 	}
 
 	if createResp.HTTPResponse.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("create key: HTTP %s", createResp.HTTPResponse.Status)
+		return "", company, clierrors.FromResponse("create key", createResp.HTTPResponse, createResp.Body)
 	}
 	if createResp.JSON201 == nil || createResp.JSON201.Key == "" {
-		return "", fmt.Errorf("create key: empty key in response")
+		return "", company, fmt.Errorf("create key: empty key in response")
 	}
 
-	return createResp.JSON201.Key, nil
+	return createResp.JSON201.Key, company, nil
 }
@@ -3,10 +3,13 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
 )
 
 func TestLogin_Success_ReturnsKey(t *testing.T) {
@@ -39,7 +42,7 @@ func TestLogin_Success_ReturnsKey(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	key, err := Login(context.Background(), srv.URL, "user@example.com", "secret")
+	key, _, err := Login(context.Background(), srv.URL, "user@example.com", "secret", nil)
 	if err != nil {
 		t.Fatalf("Login: %v", err)
 	}
@@ -64,7 +67,7 @@ func TestLogin_GetCompanies_401_ReturnsError(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := Login(context.Background(), srv.URL, "bad@example.com", "wrong")
+	_, _, err := Login(context.Background(), srv.URL, "bad@example.com", "wrong", nil)
 	if err == nil {
 		t.Fatal("expected error on 401")
 	}
@@ -88,7 +91,7 @@ func TestLogin_NoCompanies_ReturnsError(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := Login(context.Background(), srv.URL, "user@example.com", "secret")
+	_, _, err := Login(context.Background(), srv.URL, "user@example.com", "secret", nil)
 	if err == nil {
 		t.Fatal("expected error when no companies")
 	}
@@ -96,3 +99,72 @@ func TestLogin_NoCompanies_ReturnsError(t *testing.T) {
 		t.Errorf("error should mention no companies: %v", err)
 	}
 }
+
+func TestLogin_MultipleCompanies_UsesSelector(t *testing.T) {
+	var keyCompany string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api-v2/auth/companies":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"content": []map[string]interface{}{
+					{"id": "c1", "name": "Acme", "isAdmin": true},
+					{"id": "c2", "name": "Partner", "isAdmin": false},
+				},
+				"paging": map[string]interface{}{"count": 2.0, "limit": 1000.0, "offset": 0.0, "next": false},
+			})
+		case "/api-v2/auth/keys":
+			var body struct {
+				CompanyID string `json:"companyId"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			keyCompany = body.CompanyID
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"key": "k"})
+		}
+	}))
+	defer srv.Close()
+
+	_, _, err := Login(context.Background(), srv.URL, "u", "p", nil)
+	var multi *MultipleCompaniesError
+	if !errors.As(err, &multi) || len(multi.Companies) != 2 {
+		t.Fatalf("err = %v, want MultipleCompaniesError with 2 companies", err)
+	}
+	if keyCompany != "" {
+		t.Error("no key should be created without a company choice")
+	}
+
+	pickPartner := func(cs []client.CompanyListDtoBase) (client.CompanyListDtoBase, error) {
+		return ChooseCompany(cs, "", "partner")
+	}
+	_, company, err := Login(context.Background(), srv.URL, "u", "p", pickPartner)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if company.Id != "c2" || keyCompany != "c2" {
+		t.Errorf("company = %q, key created for %q; want c2", company.Id, keyCompany)
+	}
+}
+
+func TestChooseCompany_ByIDAndName(t *testing.T) {
+	companies := []client.CompanyListDtoBase{
+		{Id: "c1", Name: "Acme"},
+		{Id: "c2", Name: "Dup"},
+		{Id: "c3", Name: "dup"},
+	}
+	if c, err := ChooseCompany(companies, "c3", ""); err != nil || c.Id != "c3" {
+		t.Errorf("by id = %v, %v", c, err)
+	}
+	if c, err := ChooseCompany(companies, "", "ACME"); err != nil || c.Id != "c1" {
+		t.Errorf("by name = %v, %v", c, err)
+	}
+	if _, err := ChooseCompany(companies, "", "dup"); err == nil {
+		t.Error("ambiguous name should fail")
+	}
+	if _, err := ChooseCompany(companies, "nope", ""); err == nil {
+		t.Error("unknown id should fail")
+	}
+	if c, err := ChooseCompany(companies[:1], "", ""); err != nil || c.Id != "c1" {
+		t.Errorf("single company = %v, %v", c, err)
+	}
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// RotateHooks persist the key during Rotate.
+type RotateHooks struct {
+	// Save stores the new key (e.g. in the active profile).
+	Save func(newKey string) error
+}
+
+// OldKeyError is returned by Rotate when the new key was verified and saved
+// but the old key could not be deleted. The rotation stands: the old key is
+// still valid and has to be deleted by hand.
+type OldKeyError struct {
+	Err error
+}
+
+func (e *OldKeyError) Error() string {
+	return fmt.Sprintf("new key saved, but the old key was not deleted: %v", e.Err)
+}
+
+func (e *OldKeyError) Unwrap() error { return e.Err }
+
+// Rotate replaces oldKey with a new key for the same company:
+//  1. resolves the company of oldKey;
+//  2. creates a new key with email and password;
+//  3. verifies the new key with a test call;
+//  4. saves it with hooks.Save;
+//  5. deletes oldKey.
+//
+// If step 3 or 4 fails, the new key is deleted and the error is returned
+// together with any rollback error. Once the new key is saved it is kept: if
+// deleting oldKey fails, Rotate returns the new key with an *OldKeyError.
+// opts are passed to the API client (e.g. client.WithHTTPClient).
+func Rotate(ctx context.Context, baseURL, email, password, oldKey string, hooks RotateHooks, opts ...client.ClientOption) (newKey string, err error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	api, err := client.NewClientWithResponses(baseURL, opts...)
+	if err != nil {
+		return "", fmt.Errorf("create client: %w", err)
+	}
+
+	company, err := companyForKey(ctx, api, oldKey)
+	if err != nil {
+		return "", fmt.Errorf("check current key: %w", err)
+	}
+
+	createResp, err := api.AuthKeyControllerCreateWithResponse(ctx, client.AuthKeyControllerCreateJSONRequestBody{
+		Login:     email,
+		Password:  password,
+		CompanyId: company.Id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create key: %w", err)
+	}
+	if createResp.HTTPResponse.StatusCode != http.StatusCreated {
+		return "", clierrors.FromResponse("create key", createResp.HTTPResponse, createResp.Body)
+	}
+	if createResp.JSON201 == nil || createResp.JSON201.Key == "" {
+		return "", fmt.Errorf("create key: empty key in response")
+	}
+	newKey = createResp.JSON201.Key
+
+	rollback := func(err error) error {
+		if derr := deleteKey(ctx, api, newKey); derr != nil {
+			return fmt.Errorf("%w (rollback failed: %w)", err, derr)
+		}
+		return fmt.Errorf("%w (rolled back)", err)
+	}
+
+	verified, err := companyForKey(ctx, api, newKey)
+	if err != nil {
+		return "", rollback(fmt.Errorf("verify new key: %w", err))
+	}
+	if verified.Id != company.Id {
+		return "", rollback(fmt.Errorf("verify new key: company %s, want %s", verified.Id, company.Id))
+	}
+
+	if err := hooks.Save(newKey); err != nil {
+		return "", rollback(fmt.Errorf("save new key: %w", err))
+	}
+
+	if err := deleteKey(ctx, api, oldKey); err != nil {
+		return newKey, &OldKeyError{Err: err}
+	}
+	return newKey, nil
+}
+
+// companyForKey returns the company the key belongs to; it fails if the key is not valid.
+func companyForKey(ctx context.Context, api *client.ClientWithResponses, key string) (*client.CompanyDto, error) {
+	resp, err := api.CompanyControllerGetWithResponse(ctx, func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get company: %w", err)
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, clierrors.FromResponse("get company", resp.HTTPResponse, resp.Body)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("get company: empty response")
+	}
+	return resp.JSON200, nil
+}
+
+func deleteKey(ctx context.Context, api *client.ClientWithResponses, key string) error {
+	resp, err := api.AuthKeyControllerDeleteWithResponse(ctx, key)
+	if err != nil {
+		return fmt.Errorf("delete key: %w", err)
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return clierrors.FromResponse("delete key", resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeKeyServer is an in-memory YouGile auth API: keys map to companies.
+type fakeKeyServer struct {
+	mu        sync.Mutex
+	keys      map[string]string // key -> company ID
+	failVerif bool              // reject the freshly created key
+	failDel   string            // key whose deletion fails
+	created   int
+}
+
+func (f *fakeKeyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/api-v2/companies":
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		company, ok := f.keys[key]
+		if !ok || (f.failVerif && key == "new-key") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id":"`+company+`","title":"Acme","timestamp":0}`)
+	case r.URL.Path == "/api-v2/auth/keys" && r.Method == http.MethodPost:
+		f.created++
+		f.keys["new-key"] = "c1"
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"key":"new-key"}`)
+	case strings.HasPrefix(r.URL.Path, "/api-v2/auth/keys/") && r.Method == http.MethodDelete:
+		key := strings.TrimPrefix(r.URL.Path, "/api-v2/auth/keys/")
+		if key == f.failDel {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		delete(f.keys, key)
+		_, _ = io.WriteString(w, `{}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRotate_Success_SavesNewAndDeletesOld(t *testing.T) {
+	f := &fakeKeyServer{keys: map[string]string{"old-key": "c1"}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	saved := ""
+	newKey, err := Rotate(context.Background(), srv.URL, "u", "p", "old-key", RotateHooks{
+		Save: func(k string) error { saved = k; return nil },
+	})
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if newKey != "new-key" || saved != "new-key" {
+		t.Errorf("newKey = %q, saved = %q; want new-key", newKey, saved)
+	}
+	if _, ok := f.keys["old-key"]; ok {
+		t.Error("old key was not deleted")
+	}
+}
+
+func TestRotate_VerifyFails_DeletesNewKey(t *testing.T) {
+	f := &fakeKeyServer{keys: map[string]string{"old-key": "c1"}, failVerif: true}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	saveCalled := false
+	_, err := Rotate(context.Background(), srv.URL, "u", "p", "old-key", RotateHooks{
+		Save: func(string) error { saveCalled = true; return nil },
+	})
+	if err == nil || !strings.Contains(err.Error(), "verify new key") {
+		t.Fatalf("err = %v, want verify error", err)
+	}
+	if saveCalled {
+		t.Error("unverified key must not be saved")
+	}
+	if _, ok := f.keys["new-key"]; ok {
+		t.Error("new key should be deleted on rollback")
+	}
+	if _, ok := f.keys["old-key"]; !ok {
+		t.Error("old key must be kept")
+	}
+}
+
+func TestRotate_DeleteOldFails_KeepsNewKey(t *testing.T) {
+	f := &fakeKeyServer{keys: map[string]string{"old-key": "c1"}, failDel: "old-key"}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	current := "old-key"
+	newKey, err := Rotate(context.Background(), srv.URL, "u", "p", "old-key", RotateHooks{
+		Save: func(k string) error { current = k; return nil },
+	})
+	var oldKeyErr *OldKeyError
+	if !errors.As(err, &oldKeyErr) {
+		t.Fatalf("err = %v, want *OldKeyError", err)
+	}
+	if newKey != "new-key" || current != "new-key" {
+		t.Errorf("newKey = %q, saved = %q; want the new key kept", newKey, current)
+	}
+	if _, ok := f.keys["new-key"]; !ok {
+		t.Error("new key must not be deleted once saved")
+	}
+}
+
+func TestRotate_SaveFails_ReportsRollback(t *testing.T) {
+	f := &fakeKeyServer{keys: map[string]string{"old-key": "c1"}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	boom := errors.New("disk full")
+	_, err := Rotate(context.Background(), srv.URL, "u", "p", "old-key", RotateHooks{
+		Save: func(string) error { return boom },
+	})
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("err = %v, want wrapped save error with rollback note", err)
+	}
+	if _, ok := f.keys["new-key"]; ok {
+		t.Error("new key should be deleted on rollback")
+	}
+}
@@ -0,0 +1,172 @@
+// Package cache keeps JSON snapshots of API collections (users, columns,
+// boards, ...) on disk so that name lookups do not spend the API rate limit.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache stores one file per kind in a directory per profile. Entries record
+// the API they were fetched from (see Owner); entries of another owner are
+// treated as missing, so switching keys or companies never shows stale names.
+type Cache struct {
+	root  string
+	dir   string
+	owner string
+	now   func() time.Time
+}
+
+type entry struct {
+	Owner     string          `json:"owner"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DefaultDir returns the cache root: yougile-cli under the user cache dir
+// (e.g. ~/.cache/yougile-cli).
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("user cache dir: %w", err)
+	}
+	return filepath.Join(dir, "yougile-cli"), nil
+}
+
+// Owner returns the owner ID of entries fetched from baseURL with apiKey.
+// It is a hash, so the key itself is never written to the cache.
+func Owner(baseURL, apiKey string) string {
+	sum := sha256.Sum256([]byte(strings.TrimRight(baseURL, "/") + "\x00" + apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// New returns the cache of profile under root ("" profile means "default").
+// The profile becomes a directory name, so path separators, "." and ".." are
+// rejected.
+func New(root, profile, owner string) (*Cache, error) {
+	if profile == "" {
+		profile = "default"
+	}
+	if profile == "." || profile == ".." || strings.ContainsAny(profile, `/\`) {
+		return nil, fmt.Errorf("cache: invalid profile name %q", profile)
+	}
+	return &Cache{root: root, dir: filepath.Join(root, profile), owner: owner, now: time.Now}, nil
+}
+
+// Dir returns the profile's cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) path(kind string) string {
+	return filepath.Join(c.dir, kind+".json")
+}
+
+// Load decodes the kind's entry into v if it exists, belongs to this owner
+// and is younger than maxAge. It reports whether v was filled.
+func (c *Cache) Load(kind string, maxAge time.Duration, v interface{}) (bool, error) {
+	data, err := os.ReadFile(c.path(kind))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read cache: %w", err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false, nil // corrupt entries are refetched and overwritten
+	}
+	if e.Owner != c.owner || c.now().Sub(e.FetchedAt) > maxAge {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Info describes a kind's entry.
+type Info struct {
+	Kind      string
+	FetchedAt time.Time
+	Items     int   // elements of a list, keys of a map
+	Size      int64 // bytes on disk
+	Owned     bool  // fetched from this owner's API; entries of others are ignored
+}
+
+// Stat returns the kind's entry info; ok is false if there is no readable entry.
+func (c *Cache) Stat(kind string) (info Info, ok bool, err error) {
+	data, err := os.ReadFile(c.path(kind))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Info{Kind: kind}, false, nil
+	}
+	if err != nil {
+		return Info{Kind: kind}, false, fmt.Errorf("read cache: %w", err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Info{Kind: kind}, false, nil
+	}
+	info = Info{Kind: kind, FetchedAt: e.FetchedAt, Size: int64(len(data)), Owned: e.Owner == c.owner}
+	var list []json.RawMessage
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(e.Data, &list) == nil {
+		info.Items = len(list)
+	} else if json.Unmarshal(e.Data, &obj) == nil {
+		info.Items = len(obj)
+	}
+	return info, true, nil
+}
+
+// Clear removes every entry of the profile. It refuses a directory that is
+// not strictly inside the cache root.
+func (c *Cache) Clear() error {
+	rel, err := filepath.Rel(c.root, c.dir)
+	if err != nil || rel == "." || rel == ".." || strings.ContainsRune(rel, filepath.Separator) {
+		return fmt.Errorf("clear cache: %s is not a profile directory under %s", c.dir, c.root)
+	}
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("clear cache: %w", err)
+	}
+	return nil
+}
+
+// Save stores v as the kind's entry, fetched now.
+func (c *Cache) Save(kind string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	out, err := json.Marshal(entry{Owner: c.owner, FetchedAt: c.now().UTC(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	// Entries hold names and emails: keep them private to the user.
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, kind+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(kind)); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	return nil
+}
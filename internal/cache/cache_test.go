@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func mustNew(t *testing.T, root, profile, owner string) *Cache {
+	t.Helper()
+	c, err := New(root, profile, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCache_SaveLoad_RoundTripWithinTTL(t *testing.T) {
+	c := mustNew(t, t.TempDir(), "", Owner("https://x", "k"))
+	if err := c.Save("users", []string{"a", "b"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	var got []string
+	ok, err := c.Load("users", time.Minute, &got)
+	if err != nil || !ok || len(got) != 2 {
+		t.Fatalf("Load = %v, %v, %v", got, ok, err)
+	}
+	info, err := os.Stat(c.path("users"))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("cache file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestCache_Load_ExpiredOrOtherOwner_Misses(t *testing.T) {
+	root := t.TempDir()
+	c := mustNew(t, root, "work", Owner("https://x", "k"))
+	if err := c.Save("users", []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	c.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if ok, _ := c.Load("users", time.Minute, &got); ok {
+		t.Error("expired entry should miss")
+	}
+	other := mustNew(t, root, "work", Owner("https://x", "other-key"))
+	if ok, _ := other.Load("users", time.Hour, &got); ok {
+		t.Error("entry of another key should miss")
+	}
+	if ok, err := c.Load("boards", time.Hour, &got); ok || err != nil {
+		t.Errorf("missing kind: ok = %v, err = %v", ok, err)
+	}
+}
+
+func TestCache_StatAndClear(t *testing.T) {
+	c := mustNew(t, t.TempDir(), "", Owner("https://x", "k"))
+	if err := c.Save("users", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save("task-keys", map[string]string{"ID-1": "t1"}); err != nil {
+		t.Fatal(err)
+	}
+	for kind, want := range map[string]int{"users": 2, "task-keys": 1} {
+		info, ok, err := c.Stat(kind)
+		if err != nil || !ok || info.Items != want || !info.Owned || info.FetchedAt.IsZero() {
+			t.Errorf("Stat(%s) = %+v, %v, %v; want %d owned items", kind, info, ok, err, want)
+		}
+	}
+	if info, _, _ := mustNew(t, filepath.Dir(c.Dir()), "", Owner("https://x", "other")).Stat("users"); info.Owned {
+		t.Error("entry of another key reported as owned")
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if _, ok, err := c.Stat("users"); ok || err != nil {
+		t.Errorf("after Clear: ok = %v, err = %v", ok, err)
+	}
+}
+
+func TestCache_New_RejectsTraversal(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "cache")
+	for _, profile := range []string{"..", ".", "../..", "a/b", `a\b`} {
+		if _, err := New(root, profile, ""); err == nil {
+			t.Errorf("New(%q) = nil error, want rejection", profile)
+		}
+	}
+	outside := &Cache{root: root, dir: filepath.Dir(root)}
+	if err := outside.Clear(); err == nil {
+		t.Error("Clear outside the root succeeded")
+	}
+	if _, err := os.Stat(filepath.Dir(root)); err != nil {
+		t.Errorf("parent of the root was removed: %v", err)
+	}
+}
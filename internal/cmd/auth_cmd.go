@@ -2,75 +2,173 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/angolovin/yougile-cli/internal/auth"
 	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
 	"github.com/angolovin/yougile-cli/internal/output"
 	"github.com/angolovin/yougile-cli/pkg/client"
 	"github.com/spf13/cobra"
 )
 
+// accountFlags are the --email, --password and --password-stdin flags of
+// the auth commands that authenticate with the account password.
+type accountFlags struct {
+	email         string
+	password      string
+	passwordStdin bool
+}
+
+func (f *accountFlags) register(c *cobra.Command) {
+	c.Flags().StringVar(&f.email, "email", "", "account email")
+	c.Flags().StringVar(&f.password, "password", "", "account password (prompted for if omitted; visible in shell history)")
+	c.Flags().BoolVar(&f.passwordStdin, "password-stdin", false, "read the password from stdin")
+	c.MarkFlagsMutuallyExclusive("password", "password-stdin")
+}
+
+// credentials returns the email (required) and the password from --password,
+// --password-stdin, or a no-echo prompt when stdin is a terminal.
+func (f *accountFlags) credentials(cmd *cobra.Command) (email, password string, err error) {
+	if f.email == "" {
+		return "", "", fmt.Errorf("email is required (--email)")
+	}
+	switch {
+	case f.password != "":
+		password = f.password
+	case f.passwordStdin:
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", "", fmt.Errorf("read password from stdin: %w", err)
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	case stdinIsTerminal():
+		password, err = readPassword("Password: ")
+		if err != nil {
+			return "", "", fmt.Errorf("read password: %w", err)
+		}
+	default:
+		return "", "", fmt.Errorf("password is required: use --password-stdin or run in a terminal to be prompted")
+	}
+	if password == "" {
+		return "", "", fmt.Errorf("password is empty")
+	}
+	return f.email, password, nil
+}
+
 // NewAuthLoginCmd returns the "auth login" command.
-func NewAuthLoginCmd(resolvePath func() (string, error)) *cobra.Command {
-	var email, password string
+func NewAuthLoginCmd(g *Globals) *cobra.Command {
+	var acct accountFlags
+	var companyID, companyName string
 
 	c := &cobra.Command{
 		Use:   "login",
 		Short: "Log in with email and password, save API key to config",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if email == "" || password == "" {
-				return fmt.Errorf("email and password are required (use --email and --password)")
+			name := g.profile()
+			if name == "" {
+				name = os.Getenv(config.EnvProfile)
+			}
+			if name != "" {
+				if err := config.ValidateProfileName(name); err != nil {
+					return clierrors.NewConfigError(err)
+				}
+			}
+			email, password, err := acct.credentials(cmd)
+			if err != nil {
+				return err
 			}
 
-			path, err := resolvePath()
+			path, cfg, err := loadOrInitConfig(g)
+			if err != nil {
+				return err
+			}
+			name = cfg.ActiveProfileName(name)
+			profile, _, err := cfg.Profile(name)
 			if err != nil {
-				return fmt.Errorf("resolve config path: %w", err)
+				profile = config.Profile{BaseURL: config.DefaultBaseURL()}
+			}
+			// $YOUGILE_BASE_URL applies to this login but is not saved to the profile.
+			baseURL := profile.BaseURL
+			if env := os.Getenv(config.EnvBaseURL); env != "" {
+				baseURL = env
 			}
 
-			key, err := auth.Login(context.Background(), config.DefaultBaseURL(), email, password)
+			choose := func(companies []client.CompanyListDtoBase) (client.CompanyListDtoBase, error) {
+				return chooseCompany(cmd, companies, companyID, companyName)
+			}
+			key, company, err := auth.Login(context.Background(), baseURL, email, password, choose, client.WithHTTPClient(newHTTPClient()))
 			if err != nil {
 				return fmt.Errorf("login: %w", err)
 			}
 
-			cfg := &config.Config{
-				BaseURL: config.DefaultBaseURL(),
-				APIKey:  key,
+			profile.Email = email
+			if err := setProfileKey(cfg, path, name, profile, key); err != nil {
+				return err
 			}
 			if err := config.Save(path, cfg); err != nil {
 				return fmt.Errorf("save config: %w", err)
 			}
 
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "API key saved to %s\n", path)
-			return nil
+			v := map[string]string{"company_id": company.Id, "company": company.Name, "profile": name, "config": path}
+			return g.print(cmd.OutOrStdout(), output.Result{Value: v, Message: fmt.Sprintf("API key for %s saved to %s (profile %s)", company.Name, path, name)})
 		},
 	}
-	c.Flags().StringVar(&email, "email", "", "account email")
-	c.Flags().StringVar(&password, "password", "", "account password")
-	_ = c.MarkFlagRequired("email")
-	_ = c.MarkFlagRequired("password")
+	acct.register(c)
+	c.Flags().StringVar(&companyID, "company-id", "", "company to create the key for (see auth companies)")
+	c.Flags().StringVar(&companyName, "company-name", "", "company to create the key for, by name")
+	c.MarkFlagsMutuallyExclusive("company-id", "company-name")
 	return c
 }
 
+// chooseCompany picks the company for auth login: by --company-id or
+// --company-name, the only one, or from a numbered picker when stdin is a
+// terminal. Non-interactive runs with several candidates fail listing them.
+func chooseCompany(cmd *cobra.Command, companies []client.CompanyListDtoBase, id, name string) (client.CompanyListDtoBase, error) {
+	co, err := auth.ChooseCompany(companies, id, name)
+	var multi *auth.MultipleCompaniesError
+	if !errors.As(err, &multi) {
+		return co, err
+	}
+	if !stdinIsTerminal() {
+		return co, fmt.Errorf("%w\nchoose one with --company-id or --company-name", err)
+	}
+	options := make([]string, len(multi.Companies))
+	for i, c := range multi.Companies {
+		options[i] = c.Name
+		if c.IsAdmin {
+			options[i] += " (admin)"
+		}
+	}
+	i, err := promptChoice(cmd.InOrStdin(), cmd.ErrOrStderr(), "Select company:", options)
+	if err != nil {
+		return co, fmt.Errorf("select company: %w", err)
+	}
+	return multi.Companies[i], nil
+}
+
 // NewAuthCompaniesCmd returns the "auth companies" command (list companies by email/password).
-func NewAuthCompaniesCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var email, password string
+func NewAuthCompaniesCmd(g *Globals) *cobra.Command {
+	var acct accountFlags
 	c := &cobra.Command{
 		Use:   "companies",
 		Short: "List companies (requires email and password)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if email == "" || password == "" {
-				return fmt.Errorf("email and password are required (--email, --password)")
-			}
-			path, err := resolvePath()
+			email, password, err := acct.credentials(cmd)
 			if err != nil {
 				return err
 			}
-			cfg, err := config.Load(path)
+			_, s, err := loadSettings(g)
 			if err != nil {
-				return fmt.Errorf("load config: %w", err)
+				return err
 			}
-			api, err := client.NewClientWithResponses(cfg.BaseURL)
+			api, err := client.NewClientWithResponses(s.BaseURL, client.WithHTTPClient(newHTTPClient()))
 			if err != nil {
 				return fmt.Errorf("create client: %w", err)
 			}
@@ -82,15 +180,12 @@ func NewAuthCompaniesCmd(resolvePath func() (string, error), outputJSON func() b
 				return fmt.Errorf("get companies: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get companies: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get companies", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get companies: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
 			headers := []string{"ID", "Name", "Admin"}
 			rows := make([][]string, 0, len(resp.JSON200.Content))
 			for _, co := range resp.JSON200.Content {
@@ -100,35 +195,30 @@ func NewAuthCompaniesCmd(resolvePath func() (string, error), outputJSON func() b
 				}
 				rows = append(rows, []string{co.Id, co.Name, admin})
 			}
-			return output.PrintTable(out, headers, rows)
+			return g.print(out, output.Result{Value: resp.JSON200, Items: resp.JSON200, Table: output.NewTable(headers, rows)})
 		},
 	}
-	c.Flags().StringVar(&email, "email", "", "account email")
-	c.Flags().StringVar(&password, "password", "", "account password")
-	_ = c.MarkFlagRequired("email")
-	_ = c.MarkFlagRequired("password")
+	acct.register(c)
 	return c
 }
 
 // NewAuthKeysListCmd returns the "auth keys list" command.
-func NewAuthKeysListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var email, password, companyID string
+func NewAuthKeysListCmd(g *Globals) *cobra.Command {
+	var acct accountFlags
+	var companyID string
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List API keys (requires email and password)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if email == "" || password == "" {
-				return fmt.Errorf("email and password are required (--email, --password)")
-			}
-			path, err := resolvePath()
+			email, password, err := acct.credentials(cmd)
 			if err != nil {
 				return err
 			}
-			cfg, err := config.Load(path)
+			_, s, err := loadSettings(g)
 			if err != nil {
-				return fmt.Errorf("load config: %w", err)
+				return err
 			}
-			api, err := client.NewClientWithResponses(cfg.BaseURL)
+			api, err := client.NewClientWithResponses(s.BaseURL, client.WithHTTPClient(newHTTPClient()))
 			if err != nil {
 				return fmt.Errorf("create client: %w", err)
 			}
@@ -141,15 +231,12 @@ func NewAuthKeysListCmd(resolvePath func() (string, error), outputJSON func() bo
 				return fmt.Errorf("list keys: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list keys: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("list keys", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("list keys: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
 			keys := resp.JSON200
 			if keys == nil {
 				keys = &[]client.AuthKeyWithDetailsDto{}
@@ -163,36 +250,34 @@ func NewAuthKeysListCmd(resolvePath func() (string, error), outputJSON func() bo
 				}
 				rows = append(rows, []string{k.Key, k.CompanyId, del})
 			}
-			return output.PrintTable(out, headers, rows)
+			return g.print(out, output.Result{Value: resp.JSON200, Items: resp.JSON200, Table: output.NewTable(headers, rows)})
 		},
 	}
-	c.Flags().StringVar(&email, "email", "", "account email")
-	c.Flags().StringVar(&password, "password", "", "account password")
+	acct.register(c)
 	c.Flags().StringVar(&companyID, "company-id", "", "filter by company ID")
-	_ = c.MarkFlagRequired("email")
-	_ = c.MarkFlagRequired("password")
 	return c
 }
 
 // NewAuthKeysCreateCmd returns the "auth keys create" command.
-func NewAuthKeysCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var email, password, companyID string
+func NewAuthKeysCreateCmd(g *Globals) *cobra.Command {
+	var acct accountFlags
+	var companyID string
 	c := &cobra.Command{
 		Use:   "create",
 		Short: "Create an API key (requires email, password, company-id)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if email == "" || password == "" || companyID == "" {
-				return fmt.Errorf("email, password and company-id are required")
+			if companyID == "" {
+				return fmt.Errorf("company-id is required")
 			}
-			path, err := resolvePath()
+			email, password, err := acct.credentials(cmd)
 			if err != nil {
 				return err
 			}
-			cfg, err := config.Load(path)
+			_, s, err := loadSettings(g)
 			if err != nil {
-				return fmt.Errorf("load config: %w", err)
+				return err
 			}
-			api, err := client.NewClientWithResponses(cfg.BaseURL)
+			api, err := client.NewClientWithResponses(s.BaseURL, client.WithHTTPClient(newHTTPClient()))
 			if err != nil {
 				return fmt.Errorf("create client: %w", err)
 			}
@@ -206,44 +291,33 @@ func NewAuthKeysCreateCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("create key: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create key: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create key", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "API key created: %s\n", resp.JSON201.Key)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("API key created: %s", resp.JSON201.Key)})
 		},
 	}
-	c.Flags().StringVar(&email, "email", "", "account email")
-	c.Flags().StringVar(&password, "password", "", "account password")
+	acct.register(c)
 	c.Flags().StringVar(&companyID, "company-id", "", "company ID")
-	_ = c.MarkFlagRequired("email")
-	_ = c.MarkFlagRequired("password")
 	_ = c.MarkFlagRequired("company-id")
 	return c
 }
 
 // NewAuthKeysDeleteCmd returns the "auth keys delete" command.
-func NewAuthKeysDeleteCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewAuthKeysDeleteCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete [key]",
 		Short: "Delete an API key by key value",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			path, err := resolvePath()
+			_, s, err := loadSettings(g)
 			if err != nil {
 				return err
 			}
-			cfg, err := config.Load(path)
-			if err != nil {
-				return fmt.Errorf("load config: %w", err)
-			}
-			api, err := client.NewClientWithResponses(cfg.BaseURL)
+			api, err := client.NewClientWithResponses(s.BaseURL, client.WithHTTPClient(newHTTPClient()))
 			if err != nil {
 				return fmt.Errorf("create client: %w", err)
 			}
@@ -253,35 +327,214 @@ func NewAuthKeysDeleteCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("delete key: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("delete key: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("delete key", resp.HTTPResponse, resp.Body)
 			}
-			out := cmd.OutOrStdout()
-			_, err = fmt.Fprintf(out, "API key deleted\n")
-			return err
+			return g.print(cmd.OutOrStdout(), output.Result{Value: map[string]string{"deleted": keyFingerprint(key)}, Message: "API key deleted"})
+		},
+	}
+}
+
+// keyFingerprint identifies an API key without revealing it.
+func keyFingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "SHA256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// authStatus is the "auth status" report.
+type authStatus struct {
+	Profile        string                  `json:"profile"`
+	BaseURL        string                  `json:"base_url"`
+	KeyFingerprint string                  `json:"key_fingerprint"`
+	KeySource      config.Source           `json:"key_source"`
+	Valid          bool                    `json:"valid"`
+	Company        *client.CompanyDto      `json:"company,omitempty"`
+	User           *client.UserListDtoBase `json:"user,omitempty"`
+	Error          string                  `json:"error,omitempty"`
+}
+
+// NewAuthStatusCmd returns the "auth status" command.
+func NewAuthStatusCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"whoami"},
+		Short:   "Check that the saved API key works and show its company and owner",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, s, err := loadSettings(g)
+			if err != nil {
+				return err
+			}
+			if err := resolveStoredKey(cfg, &s); err != nil {
+				return err
+			}
+			if s.APIKey == "" {
+				return clierrors.NewConfigError(s.MissingAPIKeyError())
+			}
+			api, err := NewAPIClient(config.Profile{BaseURL: s.BaseURL, APIKey: s.APIKey})
+			if err != nil {
+				return fmt.Errorf("create API client: %w", err)
+			}
+
+			st := authStatus{
+				Profile:        s.Profile,
+				BaseURL:        s.BaseURL,
+				KeyFingerprint: keyFingerprint(s.APIKey),
+				KeySource:      s.APIKeySource,
+			}
+			ctx := context.Background()
+			resp, err := api.CompanyControllerGetWithResponse(ctx)
+			if err != nil {
+				return fmt.Errorf("get company: %w", err)
+			}
+			var keyErr error
+			switch code := resp.HTTPResponse.StatusCode; {
+			case code == 200 && resp.JSON200 != nil:
+				st.Valid = true
+				st.Company = resp.JSON200
+			case code == 401 || code == 403:
+				// A revoked or unknown key: report it, then fail with the auth exit code.
+				keyErr = apiError("check key", resp.HTTPResponse, resp.Body)
+				st.Error = keyErr.Error()
+			default:
+				return apiError("get company", resp.HTTPResponse, resp.Body)
+			}
+			if st.Valid && s.Email != "" {
+				st.User = findUserByEmail(ctx, api, s.Email)
+			}
+
+			if err := g.print(cmd.OutOrStdout(), output.Result{Value: st, Message: st.String()}); err != nil {
+				return err
+			}
+			return keyErr
+		},
+	}
+}
+
+// findUserByEmail returns the company user with email, or nil if the lookup fails.
+func findUserByEmail(ctx context.Context, api *client.ClientWithResponses, email string) *client.UserListDtoBase {
+	resp, err := api.UserControllerSearchWithResponse(ctx, &client.UserControllerSearchParams{Email: &email})
+	if err != nil || resp.JSON200 == nil {
+		return nil
+	}
+	for _, u := range resp.JSON200.Content {
+		if strings.EqualFold(u.Email, email) {
+			return &u
+		}
+	}
+	return nil
+}
+
+// String is the human-readable report.
+func (st authStatus) String() string {
+	company, user, status := "-", "unknown", "valid"
+	if st.Company != nil {
+		company = fmt.Sprintf("%s (%s)", st.Company.Title, st.Company.Id)
+	}
+	if st.User != nil {
+		user = fmt.Sprintf("%s <%s>", st.User.RealName, st.User.Email)
+		if st.User.IsAdmin != nil && *st.User.IsAdmin {
+			user += " (admin)"
+		}
+	}
+	if !st.Valid {
+		status = "invalid: " + st.Error
+	}
+	return fmt.Sprintf("Profile:  %s\nBase URL: %s\nKey:      %s (%s)\nCompany:  %s\nUser:     %s\nStatus:   %s",
+		st.Profile, st.BaseURL, st.KeyFingerprint, st.KeySource, company, user, status)
+}
+
+// NewAuthKeysRotateCmd returns the "auth keys rotate" command.
+func NewAuthKeysRotateCmd(g *Globals) *cobra.Command {
+	var acct accountFlags
+	c := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the active profile's API key with a new one and delete the old key",
+		Long: `Create a new key for the company of the active profile's key, verify it,
+save it to the profile, then delete the old key. If the new key cannot be
+verified or saved it is deleted and the old one is kept. If only deleting
+the old key fails, the new key stays saved and the old one must be deleted
+by hand (auth keys list, auth keys delete). --email defaults to the email
+saved by auth login.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, s, err := loadSettings(g)
+			if err != nil {
+				return err
+			}
+			if err := resolveStoredKey(cfg, &s); err != nil {
+				return err
+			}
+			if s.APIKey == "" {
+				return clierrors.NewConfigError(s.MissingAPIKeyError())
+			}
+			if s.APIKeySource == config.SourceEnv {
+				return clierrors.NewConfigError(fmt.Errorf("api key comes from %s; rotate it where it is set", config.EnvAPIKey))
+			}
+			if acct.email == "" {
+				acct.email = s.Email
+			}
+			email, password, err := acct.credentials(cmd)
+			if err != nil {
+				return err
+			}
+
+			profile, _, err := cfg.Profile(s.Profile)
+			if err != nil {
+				return clierrors.NewConfigError(err)
+			}
+			oldKey := s.APIKey
+			saveKey := func(key string) error {
+				if err := setProfileKey(cfg, s.ConfigPath, s.Profile, profile, key); err != nil {
+					return err
+				}
+				return config.Save(s.ConfigPath, cfg)
+			}
+			newKey, err := auth.Rotate(context.Background(), s.BaseURL, email, password, oldKey, auth.RotateHooks{Save: saveKey}, client.WithHTTPClient(newHTTPClient()))
+			var oldKeyErr *auth.OldKeyError
+			if err != nil && !errors.As(err, &oldKeyErr) {
+				return fmt.Errorf("rotate key: %w", err)
+			}
+
+			if perr := g.print(cmd.OutOrStdout(), output.Result{
+				Value: map[string]string{
+					"profile":         s.Profile,
+					"old_fingerprint": keyFingerprint(oldKey),
+					"new_fingerprint": keyFingerprint(newKey),
+				},
+				Message: fmt.Sprintf("API key rotated for profile %s: %s -> %s", s.Profile, keyFingerprint(oldKey), keyFingerprint(newKey)),
+			}); perr != nil {
+				return perr
+			}
+			if oldKeyErr != nil {
+				return fmt.Errorf("rotate key: %w; the old key %s is still valid: find it with \"yougile auth keys list\" and delete it with \"yougile auth keys delete <key>\"", oldKeyErr, keyFingerprint(oldKey))
+			}
+			return nil
 		},
 	}
+	acct.register(c)
+	return c
 }
 
 // NewAuthKeysCmd returns the "auth keys" parent command.
-func NewAuthKeysCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewAuthKeysCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "keys",
 		Short: "Manage API keys",
 	}
-	c.AddCommand(NewAuthKeysListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewAuthKeysCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewAuthKeysDeleteCmd(resolvePath, outputJSON))
+	c.AddCommand(NewAuthKeysListCmd(g))
+	c.AddCommand(NewAuthKeysCreateCmd(g))
+	c.AddCommand(NewAuthKeysDeleteCmd(g))
+	c.AddCommand(NewAuthKeysRotateCmd(g))
 	return c
 }
 
-// NewAuthCmd returns the "auth" parent command with login, companies, keys.
-func NewAuthCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+// NewAuthCmd returns the "auth" parent command with login, companies, status, keys.
+func NewAuthCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "auth",
 		Short: "Authentication and API keys",
 	}
-	c.AddCommand(NewAuthLoginCmd(resolvePath))
-	c.AddCommand(NewAuthCompaniesCmd(resolvePath, outputJSON))
-	c.AddCommand(NewAuthKeysCmd(resolvePath, outputJSON))
+	c.AddCommand(NewAuthLoginCmd(g))
+	c.AddCommand(NewAuthCompaniesCmd(g))
+	c.AddCommand(NewAuthStatusCmd(g))
+	c.AddCommand(NewAuthKeysCmd(g))
 	return c
 }
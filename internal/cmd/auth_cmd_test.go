@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+// runAccountFlags parses args into accountFlags and returns the resolved credentials.
+func runAccountFlags(t *testing.T, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	var acct accountFlags
+	var email, password string
+	var credErr error
+	c := &cobra.Command{
+		Use: "test",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			email, password, credErr = acct.credentials(cmd)
+			return nil
+		},
+	}
+	acct.register(c)
+	c.SetArgs(args)
+	c.SetIn(strings.NewReader(stdin))
+	if err := c.Execute(); err != nil {
+		return "", "", err
+	}
+	return email, password, credErr
+}
+
+func TestAccountFlags_PasswordStdin_TrimsNewline(t *testing.T) {
+	email, password, err := runAccountFlags(t, "s3cret\n", "--email", "a@b.c", "--password-stdin")
+	if err != nil {
+		t.Fatalf("credentials: %v", err)
+	}
+	if email != "a@b.c" || password != "s3cret" {
+		t.Errorf("got %q/%q, want a@b.c/s3cret", email, password)
+	}
+}
+
+func TestAccountFlags_NoPasswordNoTTY_Errors(t *testing.T) {
+	old := stdinIsTerminal
+	stdinIsTerminal = func() bool { return false }
+	defer func() { stdinIsTerminal = old }()
+
+	_, _, err := runAccountFlags(t, "", "--email", "a@b.c")
+	if err == nil || !strings.Contains(err.Error(), "--password-stdin") {
+		t.Fatalf("err = %v, want hint about --password-stdin", err)
+	}
+}
+
+func TestAccountFlags_NoPasswordTTY_Prompts(t *testing.T) {
+	oldTTY, oldRead := stdinIsTerminal, readPassword
+	stdinIsTerminal = func() bool { return true }
+	readPassword = func(string) (string, error) { return "typed", nil }
+	defer func() { stdinIsTerminal, readPassword = oldTTY, oldRead }()
+
+	_, password, err := runAccountFlags(t, "", "--email", "a@b.c")
+	if err != nil || password != "typed" {
+		t.Fatalf("password = %q, %v; want typed", password, err)
+	}
+}
+
+func TestAccountFlags_PasswordAndStdin_Conflict(t *testing.T) {
+	if _, _, err := runAccountFlags(t, "x", "--email", "a@b.c", "--password", "p", "--password-stdin"); err == nil {
+		t.Fatal("expected error for --password with --password-stdin")
+	}
+}
+
+func TestAuthStatusCmd_ValidKey_ReportsCompanyAndUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api-v2/companies":
+			_, _ = io.WriteString(w, `{"id":"c1","title":"Acme","timestamp":0}`)
+		case "/api-v2/users":
+			if r.URL.Query().Get("email") != "me@acme.io" {
+				t.Errorf("users lookup email = %q", r.URL.Query().Get("email"))
+			}
+			_, _ = io.WriteString(w, `{"content":[{"id":"u1","email":"me@acme.io","realName":"Me","isAdmin":true}],"paging":{}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	g := statusGlobals(t, srv.URL)
+
+	c := NewAuthStatusCmd(g)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Acme (c1)", "Me <me@acme.io> (admin)", "Status:   valid", "SHA256:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "the-key") {
+		t.Error("output must not contain the raw key")
+	}
+}
+
+func TestAuthStatusCmd_RevokedKey_ExitsWithAuthCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c := NewAuthStatusCmd(statusGlobals(t, srv.URL))
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	err := c.Execute()
+	if got := clierrors.ExitCode(err); got != clierrors.ExitCodeAuth {
+		t.Errorf("exit code = %d (err %v), want %d", got, err, clierrors.ExitCodeAuth)
+	}
+	if !strings.Contains(buf.String(), "Status:   invalid") {
+		t.Errorf("output should report invalid key:\n%s", buf.String())
+	}
+}
+
+func TestAuthLoginCmd_InvalidProfileName_ConfigError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	g := statusGlobals(t, srv.URL)
+	t.Setenv(config.EnvProfile, "../../etc")
+	c := NewAuthLoginCmd(g)
+	c.SetArgs([]string{"--email", "me@acme.io", "--password-stdin"})
+	c.SetIn(strings.NewReader("secret\n"))
+	c.SetOut(io.Discard)
+	c.SetErr(io.Discard)
+	err := c.Execute()
+	if got := clierrors.ExitCode(err); got != clierrors.ExitCodeConfig {
+		t.Errorf("exit code = %d (err %v), want %d", got, err, clierrors.ExitCodeConfig)
+	}
+}
+
+// statusGlobals writes a config pointing at baseURL and returns Globals for it.
+func statusGlobals(t *testing.T, baseURL string) *Globals {
+	t.Helper()
+	for _, k := range []string{config.EnvAPIKey, config.EnvBaseURL, config.EnvProfile} {
+		t.Setenv(k, "")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := "base_url: " + baseURL + "\napi_key: the-key\nemail: me@acme.io\n"
+	if err := os.WriteFile(path, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return &Globals{
+		ResolvePath: func() (string, error) { return path, nil },
+		Output:      func() string { return "table" },
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -11,74 +10,80 @@ import (
 )
 
 // NewBoardsListCmd returns the "boards list" command.
-func NewBoardsListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewBoardsListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var title, projectID string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List boards",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
 
-			params := &client.BoardControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.BoardControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
 			if projectID != "" {
-				params.ProjectId = strPtr(projectID)
+				id, err := g.newNames(context.Background(), api, s).resolveProject(projectID)
+				if err != nil {
+					return err
+				}
+				params.ProjectId = strPtr(id)
 			}
 
-			resp, err := api.BoardControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list boards: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list boards: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list boards: empty response")
-			}
-
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Title", "ProjectId"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, b := range resp.JSON200.Content {
-				rows = append(rows, []string{b.Id, b.Title, b.ProjectId})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.BoardListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.BoardControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list boards: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list boards", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list boards: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			n := g.lookupNames(context.Background(), api, s)
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(boards []client.BoardListDtoBase, paging client.PagingMetadata) output.Result {
+				table := &output.Table{Columns: []output.Column{{Header: "ID"}, {Header: "Title"}, {Header: "Project", Field: "projectId"}}}
+				for _, b := range boards {
+					table.Rows = append(table.Rows, []string{b.Id, b.Title, n.project(b.ProjectId)})
+				}
+				return output.Result{Value: client.BoardListDto{Content: boards, Paging: paging}, Items: boards, Table: table}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
-	c.Flags().StringVar(&projectID, "project-id", "", "filter by project ID")
+	refFlag(c, &projectID, "project", "filter by project (ID or name)")
 	return c
 }
 
 // NewBoardsCreateCmd returns the "boards create" command.
-func NewBoardsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewBoardsCreateCmd(g *Globals) *cobra.Command {
 	var title, projectID string
 	c := &cobra.Command{
 		Use:   "create",
 		Short: "Create a board",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if title == "" || projectID == "" {
-				return fmt.Errorf("title and project-id are required")
+				return fmt.Errorf("title and project are required (--title, --project)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			projectID, err := g.newNames(context.Background(), api, s).resolveProject(projectID)
 			if err != nil {
 				return err
 			}
@@ -88,39 +93,37 @@ func NewBoardsCreateCmd(resolvePath func() (string, error), outputJSON func() bo
 				return fmt.Errorf("create board: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create board: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create board", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Board created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Board created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "board title")
-	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
+	refFlag(c, &projectID, "project", "project ID or name")
 	_ = c.MarkFlagRequired("title")
-	_ = c.MarkFlagRequired("project-id")
 	return c
 }
 
 // NewBoardsUpdateCmd returns the "boards update" command.
-func NewBoardsUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewBoardsUpdateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a board",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveBoard(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			body := client.BoardControllerUpdateJSONRequestBody{}
 			if cmd.Flags().Changed("title") {
 				body.Title = &title
@@ -130,14 +133,10 @@ func NewBoardsUpdateCmd(resolvePath func() (string, error), outputJSON func() bo
 				return fmt.Errorf("update board: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update board: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update board", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Board updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Board updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "board title")
@@ -145,49 +144,47 @@ func NewBoardsUpdateCmd(resolvePath func() (string, error), outputJSON func() bo
 }
 
 // NewBoardGetCmd returns the "boards get" command.
-func NewBoardGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewBoardGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
-		Short: "Get board by ID",
+		Short: `Get board by ID or name ("Board" or "Project/Board")`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveBoard(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 
 			resp, err := api.BoardControllerGetWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("get board: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get board: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get board", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get board: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewBoardsCmd returns the "boards" parent command.
-func NewBoardsCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewBoardsCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "boards",
 		Short: "Manage boards",
 	}
-	c.AddCommand(NewBoardsListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewBoardGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewBoardsCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewBoardsUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewBoardsListCmd(g))
+	c.AddCommand(NewBoardGetCmd(g))
+	c.AddCommand(NewBoardsCreateCmd(g))
+	c.AddCommand(NewBoardsUpdateCmd(g))
 	return c
 }
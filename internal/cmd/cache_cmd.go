@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/angolovin/yougile-cli/internal/cache"
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// cacheEntryStatus is one row of "cache status".
+type cacheEntryStatus struct {
+	Kind      string     `json:"kind"`
+	Items     int        `json:"items"`
+	FetchedAt *time.Time `json:"fetched_at,omitempty"`
+	TTL       string     `json:"ttl"`
+	// State is fresh, stale, missing, or other-account (fetched with another
+	// base URL or key, so it is ignored).
+	State string `json:"state"`
+}
+
+// NewCacheStatusCmd returns the "cache status" command.
+func NewCacheStatusCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show what is cached for the profile and how old it is",
+		RunE: func(c *cobra.Command, args []string) error {
+			cfg, s, err := loadSettings(g)
+			if err != nil {
+				return err
+			}
+			if err := resolveStoredKey(cfg, &s); err != nil {
+				return err
+			}
+			ch := profileCache(s)
+			if ch == nil {
+				return fmt.Errorf("cache status: no user cache directory")
+			}
+
+			kinds := make([]string, 0, len(cacheKinds)+1)
+			for _, k := range cacheKinds {
+				kinds = append(kinds, k.name)
+			}
+			kinds = append(kinds, "task-keys")
+
+			entries := make([]cacheEntryStatus, 0, len(kinds))
+			table := output.NewTable([]string{"Kind", "Items", "Updated", "Age", "TTL", "State"}, nil)
+			now := time.Now()
+			for _, kind := range kinds {
+				ttl := kindTTL(kind, s.CacheTTL)
+				info, ok, err := ch.Stat(kind)
+				if err != nil {
+					return err
+				}
+				e := cacheEntryStatus{Kind: kind, TTL: ttl.String(), State: "missing"}
+				updated, age := "", ""
+				if ok {
+					e.Items, e.FetchedAt = info.Items, &info.FetchedAt
+					updated = info.FetchedAt.Local().Format("2006-01-02 15:04")
+					age = now.Sub(info.FetchedAt).Round(time.Second).String()
+					switch {
+					case !info.Owned:
+						e.State = "other-account"
+					case now.Sub(info.FetchedAt) > ttl:
+						e.State = "stale"
+					default:
+						e.State = "fresh"
+					}
+				}
+				entries = append(entries, e)
+				table.Rows = append(table.Rows, []string{kind, fmt.Sprint(e.Items), updated, age, e.TTL, e.State})
+			}
+			value := struct {
+				Dir     string             `json:"dir"`
+				Entries []cacheEntryStatus `json:"entries"`
+			}{ch.Dir(), entries}
+			return g.print(c.OutOrStdout(), output.Result{Value: value, Items: entries, Table: table})
+		},
+	}
+}
+
+// NewCacheRefreshCmd returns the "cache refresh" command.
+func NewCacheRefreshCmd(g *Globals) *cobra.Command {
+	kinds := make([]string, len(cacheKinds))
+	for i, k := range cacheKinds {
+		kinds[i] = k.name
+	}
+	return &cobra.Command{
+		Use:       "refresh [kind...]",
+		Short:     "Refetch cached lists now",
+		Long:      "Refetch the cached lists (all, or the given kinds: " + strings.Join(kinds, ", ") + ").",
+		ValidArgs: kinds,
+		Args:      cobra.OnlyValidArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			if g.noCache() {
+				return fmt.Errorf("cache refresh: the cache is disabled by --no-cache")
+			}
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			n := g.newNames(context.Background(), api, s)
+			if n.cache == nil {
+				return fmt.Errorf("cache refresh: no user cache directory")
+			}
+			if len(args) == 0 {
+				args = kinds
+			}
+
+			type refreshed struct {
+				Kind  string `json:"kind"`
+				Items int    `json:"items"`
+			}
+			var done []refreshed
+			var parts []string
+			for _, kind := range args {
+				count, err := n.refreshKind(kind)
+				if err != nil {
+					return fmt.Errorf("refresh %s: %w", kind, err)
+				}
+				done = append(done, refreshed{kind, count})
+				parts = append(parts, fmt.Sprintf("%s (%d)", kind, count))
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: done, Message: "Cache refreshed: " + strings.Join(parts, ", ")})
+		},
+	}
+}
+
+// NewCacheClearCmd returns the "cache clear" command.
+func NewCacheClearCmd(g *Globals) *cobra.Command {
+	var allProfiles bool
+	c := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete the profile's cached lists",
+		RunE: func(c *cobra.Command, args []string) error {
+			root, err := cache.DefaultDir()
+			if err != nil {
+				return err
+			}
+			dir := root
+			if allProfiles {
+				if err := os.RemoveAll(root); err != nil {
+					return fmt.Errorf("clear cache: %w", err)
+				}
+			} else {
+				_, s, err := loadSettings(g)
+				if err != nil {
+					return err
+				}
+				ch, err := cache.New(root, s.Profile, "")
+				if err != nil {
+					return err
+				}
+				if err := ch.Clear(); err != nil {
+					return err
+				}
+				dir = ch.Dir()
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: map[string]string{"cleared": dir}, Message: "Cache cleared: " + dir})
+		},
+	}
+	c.Flags().BoolVar(&allProfiles, "all-profiles", false, "clear the cache of every profile")
+	return c
+}
+
+// NewCacheCmd returns the "cache" parent command.
+func NewCacheCmd(g *Globals) *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache of projects, boards, columns, users and stickers",
+		Long: `Lists used to show and resolve names (projects, boards, columns, users,
+departments, stickers) are cached per profile under the user cache
+directory, so repeated commands do not spend the API rate limit.
+Projects, boards and columns are refetched after 10 minutes, the others
+after an hour; set cache_ttl in the config (e.g. "30m") to use one TTL for
+all. --no-cache skips the cache for a single command.`,
+	}
+	cc.AddCommand(NewCacheStatusCmd(g))
+	cc.AddCommand(NewCacheRefreshCmd(g))
+	cc.AddCommand(NewCacheClearCmd(g))
+	return cc
+}
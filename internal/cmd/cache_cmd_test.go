@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func runCmd(t *testing.T, c *cobra.Command, args ...string) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetArgs(args)
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute %v: %v", args, err)
+	}
+	return buf.String()
+}
+
+func TestCacheCmd_RefreshStatusClear(t *testing.T) {
+	srv := newResolveServer(t)
+	g := statusGlobals(t, srv.URL)
+
+	out := runCmd(t, NewCacheCmd(g), "refresh", "users", "columns")
+	if !strings.Contains(out, "users (2), columns (3)") {
+		t.Errorf("refresh output = %q", out)
+	}
+	out = runCmd(t, NewCacheCmd(g), "status")
+	for _, want := range []string{"users", "2", "1h0m0s", "fresh", "boards", "missing"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("status missing %q:\n%s", want, out)
+		}
+	}
+	runCmd(t, NewCacheCmd(g), "clear")
+	if out = runCmd(t, NewCacheCmd(g), "status"); strings.Contains(out, "fresh") {
+		t.Errorf("status after clear:\n%s", out)
+	}
+}
+
+func TestNoCache_FetchesEveryRun(t *testing.T) {
+	srv, hits := namesServer(t)
+	g := statusGlobals(t, srv.URL)
+	g.NoCache = func() bool { return true }
+
+	runTasksList(t, g)
+	runTasksList(t, g)
+	if hits["/api-v2/users"] != 2 {
+		t.Errorf("users fetched %d times, want 2 with --no-cache", hits["/api-v2/users"])
+	}
+}
+
+func TestCacheClear_TraversalProfile_DeletesNothing(t *testing.T) {
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	t.Setenv("HOME", cacheHome)
+	keep := filepath.Join(cacheHome, "keep.txt")
+	if err := os.WriteFile(keep, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(t.TempDir(), "config.yaml")
+	g := &Globals{
+		ResolvePath: func() (string, error) { return missing, nil },
+		Profile:     func() string { return "../.." },
+	}
+	c := NewCacheCmd(g)
+	c.SetArgs([]string{"clear"})
+	c.SetOut(io.Discard)
+	c.SetErr(io.Discard)
+	if err := c.Execute(); err == nil {
+		t.Error("cache clear --profile ../.. succeeded")
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Errorf("file outside the cache was removed: %v", err)
+	}
+}
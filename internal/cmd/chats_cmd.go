@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -12,58 +11,57 @@ import (
 )
 
 // NewChatsListCmd returns the "chats list" command (group chats).
-func NewChatsListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var title string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List group chats",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
-			params := &client.GroupChatControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.GroupChatControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
-			resp, err := api.GroupChatControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list chats: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list chats: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list chats: empty response")
-			}
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Title"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, ch := range resp.JSON200.Content {
-				rows = append(rows, []string{ch.Id, ch.Title})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.GroupChatListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.GroupChatControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list chats: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list chats", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list chats: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(chats []client.GroupChatListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Title"}
+				rows := make([][]string, 0, len(chats))
+				for _, ch := range chats {
+					rows = append(rows, []string{ch.Id, ch.Title})
+				}
+				return output.Result{Value: client.GroupChatListDto{Content: chats, Paging: paging}, Items: chats, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
 	return c
 }
 
 // NewChatsCreateCmd returns the "chats create" command.
-func NewChatsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsCreateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "create",
@@ -72,7 +70,7 @@ func NewChatsCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 			if title == "" {
 				return fmt.Errorf("title is required (--title)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -87,17 +85,13 @@ func NewChatsCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 				return fmt.Errorf("create chat: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create chat: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create chat", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Chat created: id=%s\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Chat created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "chat title")
@@ -106,14 +100,14 @@ func NewChatsCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 }
 
 // NewChatsUpdateCmd returns the "chats update" command.
-func NewChatsUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsUpdateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a group chat",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -127,14 +121,10 @@ func NewChatsUpdateCmd(resolvePath func() (string, error), outputJSON func() boo
 				return fmt.Errorf("update chat: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update chat: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update chat", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Chat updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Chat updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "chat title")
@@ -142,13 +132,13 @@ func NewChatsUpdateCmd(resolvePath func() (string, error), outputJSON func() boo
 }
 
 // NewChatGetCmd returns the "chats get" command.
-func NewChatGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
 		Short: "Get group chat by ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -158,71 +148,65 @@ func NewChatGetCmd(resolvePath func() (string, error), outputJSON func() bool) *
 				return fmt.Errorf("get chat: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get chat: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get chat", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get chat: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewChatsMessagesListCmd returns the "chats messages list" command.
-func NewChatsMessagesListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsMessagesListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	c := &cobra.Command{
 		Use:   "list [chat-id]",
 		Short: "List messages in a chat",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
 			chatID := args[0]
-			params := &client.ChatMessageControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
-			resp, err := api.ChatMessageControllerSearchWithResponse(context.Background(), chatID, params)
-			if err != nil {
-				return fmt.Errorf("list messages: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list messages: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list messages: empty response")
-			}
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"Id", "FromUserId", "Text"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, m := range resp.JSON200.Content {
-				rows = append(rows, []string{strconv.FormatFloat(float64(m.Id), 'f', 0, 32), m.FromUserId, m.Text})
-			}
-			return output.PrintTable(out, headers, rows)
+			params := client.ChatMessageControllerSearchParams{}
+			fetch := func(ctx context.Context, limit, offset int) ([]client.ChatMessageListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.ChatMessageControllerSearchWithResponse(ctx, chatID, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list messages: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list messages", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list messages: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(messages []client.ChatMessageListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"Id", "FromUserId", "Text"}
+				rows := make([][]string, 0, len(messages))
+				for _, m := range messages {
+					rows = append(rows, []string{strconv.FormatFloat(float64(m.Id), 'f', 0, 32), m.FromUserId, m.Text})
+				}
+				return output.Result{Value: client.ChatMessageListDto{Content: messages, Paging: paging}, Items: messages, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	return c
 }
 
 // NewChatsMessagesSendCmd returns the "chats messages send" command.
-func NewChatsMessagesSendCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsMessagesSendCmd(g *Globals) *cobra.Command {
 	var text string
 	c := &cobra.Command{
 		Use:   "send [chat-id]",
@@ -232,7 +216,7 @@ func NewChatsMessagesSendCmd(resolvePath func() (string, error), outputJSON func
 			if text == "" {
 				return fmt.Errorf("message text is required (--text)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -247,17 +231,13 @@ func NewChatsMessagesSendCmd(resolvePath func() (string, error), outputJSON func
 				return fmt.Errorf("send message: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("send message: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("send message", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Message id: %v\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Message id: %v", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&text, "text", "", "message text")
@@ -266,14 +246,14 @@ func NewChatsMessagesSendCmd(resolvePath func() (string, error), outputJSON func
 }
 
 // NewChatsMessagesUpdateCmd returns the "chats messages update" command.
-func NewChatsMessagesUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsMessagesUpdateCmd(g *Globals) *cobra.Command {
 	var label string
 	c := &cobra.Command{
 		Use:   "update [chat-id] [message-id]",
 		Short: "Update a chat message",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -291,14 +271,10 @@ func NewChatsMessagesUpdateCmd(resolvePath func() (string, error), outputJSON fu
 				return fmt.Errorf("update message: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update message: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update message", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Message updated\n")
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: "Message updated"})
 		},
 	}
 	c.Flags().StringVar(&label, "label", "", "message label")
@@ -306,19 +282,19 @@ func NewChatsMessagesUpdateCmd(resolvePath func() (string, error), outputJSON fu
 }
 
 // NewChatsCmd returns the "chats" parent command.
-func NewChatsCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewChatsCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "chats",
 		Short: "Group chats and messages",
 	}
-	c.AddCommand(NewChatsListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewChatGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewChatsCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewChatsUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewChatsListCmd(g))
+	c.AddCommand(NewChatGetCmd(g))
+	c.AddCommand(NewChatsCreateCmd(g))
+	c.AddCommand(NewChatsUpdateCmd(g))
 	msgs := &cobra.Command{Use: "messages", Short: "Chat messages"}
-	msgs.AddCommand(NewChatsMessagesListCmd(resolvePath, outputJSON))
-	msgs.AddCommand(NewChatsMessagesSendCmd(resolvePath, outputJSON))
-	msgs.AddCommand(NewChatsMessagesUpdateCmd(resolvePath, outputJSON))
+	msgs.AddCommand(NewChatsMessagesListCmd(g))
+	msgs.AddCommand(NewChatsMessagesSendCmd(g))
+	msgs.AddCommand(NewChatsMessagesUpdateCmd(g))
 	c.AddCommand(msgs)
 	return c
 }
@@ -2,23 +2,35 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/internal/transport"
 	"github.com/angolovin/yougile-cli/pkg/client"
 )
 
-// NewAPIClient returns a YouGile API client with Bearer auth from cfg.
+// newHTTPClient returns the HTTP client for API calls: rate limited to the
+// API's 50 requests/minute and retrying 429/5xx responses.
+func newHTTPClient() *http.Client {
+	return &http.Client{Transport: transport.New(http.DefaultTransport)}
+}
+
+// NewAPIClient returns a YouGile API client with Bearer auth from profile p.
 // baseURL is normalized (no trailing slash).
-func NewAPIClient(cfg *config.Config) (*client.ClientWithResponses, error) {
-	baseURL := strings.TrimRight(cfg.BaseURL, "/")
-	if cfg.APIKey == "" {
-		return nil, fmt.Errorf("api_key not set in config")
+func NewAPIClient(p config.Profile) (*client.ClientWithResponses, error) {
+	baseURL := strings.TrimRight(p.BaseURL, "/")
+	if p.APIKey == "" {
+		return nil, clierrors.NewConfigError(fmt.Errorf("api_key not set in config"))
 	}
-	apiKey := cfg.APIKey
+	apiKey := p.APIKey
 	opts := []client.ClientOption{
+		client.WithHTTPClient(newHTTPClient()),
 		client.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
 			req.Header.Set("Authorization", "Bearer "+apiKey)
 			return nil
@@ -27,20 +39,85 @@ func NewAPIClient(cfg *config.Config) (*client.ClientWithResponses, error) {
 	return client.NewClientWithResponses(baseURL, opts...)
 }
 
-// loadConfigAndClient loads config from path and creates API client.
-// Returns error if config missing, invalid, or api_key empty.
-func loadConfigAndClient(resolvePath func() (string, error)) (*config.Config, *client.ClientWithResponses, error) {
-	path, err := resolvePath()
+// apiError returns the error for a non-success API response, keeping
+// the status, endpoint and the explanation from the response body.
+func apiError(op string, resp *http.Response, body []byte) error {
+	return clierrors.FromResponse(op, resp, body)
+}
+
+// loadConfig resolves the config path and loads the config file.
+func loadConfig(g *Globals) (string, *config.Config, error) {
+	path, err := g.ResolvePath()
+	if err != nil {
+		return "", nil, clierrors.NewConfigError(fmt.Errorf("resolve config path: %w", err))
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return "", nil, clierrors.NewConfigError(fmt.Errorf("load config: %w", err))
+	}
+	return path, cfg, nil
+}
+
+// loadOrInitConfig is loadConfig, but a missing config file yields an empty
+// config (to be created on save) instead of an error.
+func loadOrInitConfig(g *Globals) (string, *config.Config, error) {
+	path, err := g.ResolvePath()
 	if err != nil {
-		return nil, nil, fmt.Errorf("resolve config path: %w", err)
+		return "", nil, clierrors.NewConfigError(fmt.Errorf("resolve config path: %w", err))
 	}
 	cfg, err := config.Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return path, &config.Config{BaseURL: config.DefaultBaseURL()}, nil
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("load config: %w", err)
+		return "", nil, clierrors.NewConfigError(fmt.Errorf("load config: %w", err))
+	}
+	return path, cfg, nil
+}
+
+// loadSettings loads the config file, if any, and resolves the effective
+// profile, base URL and API key (flag > env > file > default).
+func loadSettings(g *Globals) (*config.Config, config.Settings, error) {
+	path, err := g.ResolvePath()
+	if err != nil {
+		return nil, config.Settings{}, clierrors.NewConfigError(fmt.Errorf("resolve config path: %w", err))
+	}
+	cfg, s, err := config.LoadSettings(path, g.profile())
+	if err != nil {
+		return nil, s, clierrors.NewConfigError(fmt.Errorf("load config: %w", err))
+	}
+	if s.Location != nil {
+		// Every date the CLI shows or parses is in local time; the configured
+		// zone replaces the system one for the rest of the process.
+		time.Local = s.Location
+	}
+	return cfg, s, nil
+}
+
+// loadConfigAndClient loads config and creates the API client for the effective settings,
+// reading the API key from the credential store if one is configured. cfg is nil when there is no config file and the key comes from the environment.
+// Returns error if config is invalid, the profile doesn't exist, or no API key is set.
+func loadConfigAndClient(g *Globals) (*config.Config, *client.ClientWithResponses, error) {
+	cfg, _, api, err := loadSession(g)
+	return cfg, api, err
+}
+
+// loadSession is loadConfigAndClient that also returns the resolved settings
+// (profile, base URL, key), e.g. to key the name cache.
+func loadSession(g *Globals) (*config.Config, config.Settings, *client.ClientWithResponses, error) {
+	cfg, s, err := loadSettings(g)
+	if err != nil {
+		return nil, s, nil, err
+	}
+	if err := resolveStoredKey(cfg, &s); err != nil {
+		return nil, s, nil, err
+	}
+	if s.APIKey == "" {
+		return nil, s, nil, clierrors.NewConfigError(s.MissingAPIKeyError())
 	}
-	api, err := NewAPIClient(cfg)
+	api, err := NewAPIClient(config.Profile{BaseURL: s.BaseURL, APIKey: s.APIKey})
 	if err != nil {
-		return nil, nil, fmt.Errorf("create API client: %w", err)
+		return nil, s, nil, fmt.Errorf("create API client: %w", err)
 	}
-	return cfg, api, nil
+	return cfg, s, api, nil
 }
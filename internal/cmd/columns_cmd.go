@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -11,74 +10,80 @@ import (
 )
 
 // NewColumnsListCmd returns the "columns list" command.
-func NewColumnsListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewColumnsListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var title, boardID string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List columns",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
 
-			params := &client.ColumnControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.ColumnControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
 			if boardID != "" {
-				params.BoardId = strPtr(boardID)
+				id, err := g.newNames(context.Background(), api, s).resolveBoard(boardID)
+				if err != nil {
+					return err
+				}
+				params.BoardId = strPtr(id)
 			}
 
-			resp, err := api.ColumnControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list columns: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list columns: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list columns: empty response")
-			}
-
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Title", "BoardId"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, col := range resp.JSON200.Content {
-				rows = append(rows, []string{col.Id, col.Title, col.BoardId})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.ColumnListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.ColumnControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list columns: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list columns", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list columns: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			n := g.lookupNames(context.Background(), api, s)
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(columns []client.ColumnListDtoBase, paging client.PagingMetadata) output.Result {
+				table := &output.Table{Columns: []output.Column{{Header: "ID"}, {Header: "Title"}, {Header: "Board", Field: "boardId"}}}
+				for _, col := range columns {
+					table.Rows = append(table.Rows, []string{col.Id, col.Title, n.board(col.BoardId)})
+				}
+				return output.Result{Value: client.ColumnListDto{Content: columns, Paging: paging}, Items: columns, Table: table}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
-	c.Flags().StringVar(&boardID, "board-id", "", "filter by board ID")
+	refFlag(c, &boardID, "board", `filter by board: ID, "Board" or "Project/Board"`)
 	return c
 }
 
 // NewColumnsCreateCmd returns the "columns create" command.
-func NewColumnsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewColumnsCreateCmd(g *Globals) *cobra.Command {
 	var title, boardID string
 	c := &cobra.Command{
 		Use:   "create",
 		Short: "Create a column",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if title == "" || boardID == "" {
-				return fmt.Errorf("title and board-id are required")
+				return fmt.Errorf("title and board are required (--title, --board)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			boardID, err := g.newNames(context.Background(), api, s).resolveBoard(boardID)
 			if err != nil {
 				return err
 			}
@@ -88,39 +93,37 @@ func NewColumnsCreateCmd(resolvePath func() (string, error), outputJSON func() b
 				return fmt.Errorf("create column: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create column: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create column", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Column created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Column created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "column title")
-	c.Flags().StringVar(&boardID, "board-id", "", "board ID")
+	refFlag(c, &boardID, "board", `board: ID, "Board" or "Project/Board"`)
 	_ = c.MarkFlagRequired("title")
-	_ = c.MarkFlagRequired("board-id")
 	return c
 }
 
 // NewColumnsUpdateCmd returns the "columns update" command.
-func NewColumnsUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewColumnsUpdateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a column",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveColumn(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			body := client.ColumnControllerUpdateJSONRequestBody{}
 			if cmd.Flags().Changed("title") {
 				body.Title = &title
@@ -130,14 +133,10 @@ func NewColumnsUpdateCmd(resolvePath func() (string, error), outputJSON func() b
 				return fmt.Errorf("update column: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update column: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update column", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Column updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Column updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "column title")
@@ -145,49 +144,47 @@ func NewColumnsUpdateCmd(resolvePath func() (string, error), outputJSON func() b
 }
 
 // NewColumnGetCmd returns the "columns get" command.
-func NewColumnGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewColumnGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
-		Short: "Get column by ID",
+		Short: `Get column by ID or name ("Column", "Board/Column" or "Project/Board/Column")`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveColumn(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 
 			resp, err := api.ColumnControllerGetWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("get column: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get column: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get column", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get column: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewColumnsCmd returns the "columns" parent command.
-func NewColumnsCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewColumnsCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "columns",
 		Short: "Manage columns",
 	}
-	c.AddCommand(NewColumnsListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewColumnGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewColumnsCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewColumnsUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewColumnsListCmd(g))
+	c.AddCommand(NewColumnGetCmd(g))
+	c.AddCommand(NewColumnsCreateCmd(g))
+	c.AddCommand(NewColumnsUpdateCmd(g))
 	return c
 }
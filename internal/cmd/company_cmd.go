@@ -2,22 +2,19 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/angolovin/yougile-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCompanyGetCmd returns the "company get" command.
-func NewCompanyGetCmd(
-	resolvePath func() (string, error),
-	outputJSON func() bool,
-) *cobra.Command {
+func NewCompanyGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get",
 		Short: "Get current company details",
 		RunE: func(c *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -27,29 +24,24 @@ func NewCompanyGetCmd(
 				return fmt.Errorf("get company: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get company: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get company", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get company: empty response")
 			}
 
 			out := c.OutOrStdout()
-			if outputJSON() {
-				return json.NewEncoder(out).Encode(resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewCompanyCmd returns the "company" parent command with get subcommand.
-func NewCompanyCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewCompanyCmd(g *Globals) *cobra.Command {
 	cc := &cobra.Command{
 		Use:   "company",
 		Short: "Company details",
 	}
-	cc.AddCommand(NewCompanyGetCmd(resolvePath, outputJSON))
+	cc.AddCommand(NewCompanyGetCmd(g))
 	return cc
 }
@@ -1,24 +1,28 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/internal/secret"
 	"github.com/spf13/cobra"
 )
 
 const apiKeyMask = "***"
 
 // NewConfigPathCmd returns the "config path" command.
-func NewConfigPathCmd(resolvePath func() (string, error)) *cobra.Command {
+func NewConfigPathCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "path",
 		Short: "Print path to config file",
 		RunE: func(c *cobra.Command, args []string) error {
-			path, err := resolvePath()
+			path, err := g.ResolvePath()
 			if err != nil {
-				return fmt.Errorf("resolve config path: %w", err)
+				return clierrors.NewConfigError(fmt.Errorf("resolve config path: %w", err))
 			}
 			_, writeErr := fmt.Fprintln(c.OutOrStdout(), path)
 			if writeErr != nil {
@@ -30,48 +34,280 @@ func NewConfigPathCmd(resolvePath func() (string, error)) *cobra.Command {
 }
 
 // NewConfigShowCmd returns the "config show" command.
-func NewConfigShowCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewConfigShowCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "show",
-		Short: "Show current config (api_key masked in human output)",
+		Short: "Show effective settings and where each comes from (api_key masked)",
 		RunE: func(c *cobra.Command, args []string) error {
-			path, err := resolvePath()
+			cfg, s, err := loadSettings(g)
 			if err != nil {
-				return fmt.Errorf("resolve config path: %w", err)
+				return err
+			}
+			if err := resolveStoredKey(cfg, &s); err != nil {
+				return err
+			}
+			key := ""
+			if s.APIKey != "" {
+				key = apiKeyMask
 			}
 
-			cfg, err := config.Load(path)
-			if err != nil {
-				return fmt.Errorf("load config: %w", err)
+			type setting struct {
+				Value  string        `json:"value"`
+				Source config.Source `json:"source"`
+			}
+			value := struct {
+				ConfigFile   string  `json:"config_file"`
+				ConfigLoaded bool    `json:"config_loaded"`
+				Profile      setting `json:"profile"`
+				BaseURL      setting `json:"base_url"`
+				APIKey       setting `json:"api_key"`
+			}{
+				ConfigFile:   s.ConfigPath,
+				ConfigLoaded: s.ConfigLoaded,
+				Profile:      setting{s.Profile, s.ProfileSource},
+				BaseURL:      setting{s.BaseURL, s.BaseURLSource},
+				APIKey:       setting{key, s.APIKeySource},
 			}
 
-			out := c.OutOrStdout()
-			if outputJSON() {
-				return json.NewEncoder(out).Encode(cfg)
+			fileState := "not found"
+			if s.ConfigLoaded {
+				fileState = "loaded"
 			}
+			headers := []string{"Setting", "Value", "Source"}
+			rows := [][]string{
+				{"config_file", s.ConfigPath, fileState},
+				{"profile", s.Profile, string(s.ProfileSource)},
+				{"base_url", s.BaseURL, string(s.BaseURLSource)},
+				{"api_key", key, string(s.APIKeySource)},
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: value, Table: output.NewTable(headers, rows)})
+		},
+	}
+}
 
-			// Human output: mask api_key
-			display := struct {
+// NewConfigListProfilesCmd returns the "config list-profiles" command.
+func NewConfigListProfilesCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-profiles",
+		Short: "List config profiles (* marks the active one)",
+		RunE: func(c *cobra.Command, args []string) error {
+			_, cfg, err := loadConfig(g)
+			if err != nil {
+				return err
+			}
+			active := cfg.ActiveProfileName(g.profile())
+			type profileJSON struct {
+				Name    string `json:"name"`
 				BaseURL string `json:"base_url"`
-				APIKey  string `json:"api_key"`
-			}{
-				BaseURL: cfg.BaseURL,
-				APIKey:  apiKeyMask,
+				Active  bool   `json:"active"`
+			}
+			list := make([]profileJSON, 0, len(cfg.Profiles)+1)
+			headers := []string{"", "Name", "BaseURL", "APIKey"}
+			rows := make([][]string, 0, len(cfg.Profiles)+1)
+			for _, name := range cfg.ProfileNames() {
+				p, _, _ := cfg.Profile(name)
+				list = append(list, profileJSON{Name: name, BaseURL: p.BaseURL, Active: name == active})
+				mark, key := "", ""
+				if name == active {
+					mark = "*"
+				}
+				if p.APIKey != "" {
+					key = apiKeyMask
+				} else if cfg.CredentialStore != "" && cfg.CredentialStore != secret.Config {
+					key = "(" + cfg.CredentialStore + ")"
+				}
+				rows = append(rows, []string{mark, name, p.BaseURL, key})
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: list, Items: list, Table: output.NewTable(headers, rows)})
+		},
+	}
+}
+
+// NewConfigUseProfileCmd returns the "config use-profile" command.
+func NewConfigUseProfileCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-profile [name]",
+		Short: "Set current_profile in config",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path, cfg, err := loadConfig(g)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			if err := config.ValidateProfileName(name); err != nil {
+				return clierrors.NewConfigError(err)
+			}
+			if !cfg.HasProfile(name) {
+				return clierrors.NewConfigError(fmt.Errorf("profile %q not found", name))
+			}
+			cfg.CurrentProfile = name
+			if name == config.DefaultProfile {
+				cfg.CurrentProfile = ""
+			}
+			if err := config.Save(path, cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: map[string]string{"current_profile": name}, Message: "Current profile: " + name})
+		},
+	}
+}
+
+// NewConfigAddProfileCmd returns the "config add-profile" command.
+func NewConfigAddProfileCmd(g *Globals) *cobra.Command {
+	var baseURL, apiKey string
+	c := &cobra.Command{
+		Use:   "add-profile [name]",
+		Short: "Add a config profile (set its key with --api-key or auth login --profile)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, cfg, err := loadOrInitConfig(g)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			if err := config.ValidateProfileName(name); err != nil {
+				return clierrors.NewConfigError(err)
+			}
+			if _, ok := cfg.Profiles[name]; ok || (name == config.DefaultProfile && cfg.APIKey != "") {
+				return fmt.Errorf("profile %q already exists", name)
+			}
+			p := config.Profile{BaseURL: baseURL}
+			if apiKey == "" {
+				cfg.SetProfile(name, p)
+			} else if err := setProfileKey(cfg, path, name, p, apiKey); err != nil {
+				return err
+			}
+			if err := config.Save(path, cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			return g.print(cmd.OutOrStdout(), output.Result{Value: map[string]string{"added": name}, Message: "Profile added: " + name})
+		},
+	}
+	c.Flags().StringVar(&baseURL, "base-url", config.DefaultBaseURL(), "YouGile API base URL")
+	c.Flags().StringVar(&apiKey, "api-key", "", "API key")
+	return c
+}
+
+// NewConfigRemoveProfileCmd returns the "config remove-profile" command.
+func NewConfigRemoveProfileCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-profile [name]",
+		Short: "Remove a config profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path, cfg, err := loadConfig(g)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			p, _, _ := cfg.Profile(name)
+			if err := cfg.RemoveProfile(name); err != nil {
+				return clierrors.NewConfigError(err)
+			}
+			if err := deleteProfileKey(cfg, path, name, p); err != nil {
+				return err
+			}
+			if err := config.Save(path, cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: map[string]string{"removed": name}, Message: "Profile removed: " + name})
+		},
+	}
+}
+
+// NewConfigSetCredentialStoreCmd returns the "config set-credential-store" command.
+// It moves the API keys of all profiles into the new store before switching.
+func NewConfigSetCredentialStoreCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-credential-store [config|keyring|file]",
+		Short: "Choose where API keys are stored and move existing keys there",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			path, cfg, err := loadOrInitConfig(g)
+			if err != nil {
+				return err
+			}
+			backend := args[0]
+			if backend == cfg.CredentialStore || (backend == secret.Config && cfg.CredentialStore == "") {
+				v := map[string]any{"credential_store": backend, "moved": 0}
+				return g.print(c.OutOrStdout(), output.Result{Value: v, Message: "Credential store already " + backend})
+			}
+			from, err := openCredentialStore(cfg, path)
+			if err != nil {
+				return err
+			}
+			to, err := openStore(backend, path)
+			if err != nil {
+				return err
+			}
+
+			names := cfg.ProfileNames()
+			if !slices.Contains(names, config.DefaultProfile) {
+				// The default profile's key may live only in the old store.
+				names = append(names, config.DefaultProfile)
+			}
+			moved := make([]string, 0, len(names)) // store accounts
+			for _, name := range names {
+				p, _, _ := cfg.Profile(name)
+				account := storeAccount(name, p)
+				key := p.APIKey
+				if from != nil {
+					key, err = from.Get(account)
+					if errors.Is(err, secret.ErrNotFound) {
+						continue
+					}
+					if err != nil {
+						return fmt.Errorf("read api key for %s: %w", name, err)
+					}
+				}
+				if key == "" {
+					continue
+				}
+				p.APIKey = key
+				if to != nil {
+					if err := to.Set(account, key); err != nil {
+						return fmt.Errorf("save api key for %s: %w", name, err)
+					}
+					p.APIKey = ""
+				}
+				cfg.SetProfile(name, p)
+				moved = append(moved, account)
+			}
+
+			cfg.CredentialStore = backend
+			if backend == secret.Config {
+				cfg.CredentialStore = ""
+			}
+			if err := config.Save(path, cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			// Only drop the old copies once the config points at the new store.
+			if from != nil {
+				for _, account := range moved {
+					if err := from.Delete(account); err != nil && !errors.Is(err, secret.ErrNotFound) {
+						return fmt.Errorf("delete old api key for %s: %w", account, err)
+					}
+				}
 			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(display)
+			v := map[string]any{"credential_store": backend, "moved": len(moved)}
+			return g.print(c.OutOrStdout(), output.Result{Value: v, Message: fmt.Sprintf("Credential store: %s (%d key(s) moved)", backend, len(moved))})
 		},
 	}
 }
 
-// NewConfigCmd returns the "config" parent command with path and show subcommands.
-func NewConfigCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+// NewConfigCmd returns the "config" parent command with path, show and profile subcommands.
+func NewConfigCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "config",
 		Short: "Manage config file",
 	}
-	c.AddCommand(NewConfigPathCmd(resolvePath))
-	c.AddCommand(NewConfigShowCmd(resolvePath, outputJSON))
+	c.AddCommand(NewConfigPathCmd(g))
+	c.AddCommand(NewConfigShowCmd(g))
+	c.AddCommand(NewConfigListProfilesCmd(g))
+	c.AddCommand(NewConfigUseProfileCmd(g))
+	c.AddCommand(NewConfigAddProfileCmd(g))
+	c.AddCommand(NewConfigRemoveProfileCmd(g))
+	c.AddCommand(NewConfigSetCredentialStoreCmd(g))
 	return c
 }
@@ -2,17 +2,24 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/internal/secret"
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
 )
 
 func TestConfigPathCmd_PrintsResolvedPath(t *testing.T) {
 	wantPath := filepath.Join(t.TempDir(), "config.yaml")
 	resolvePath := func() (string, error) { return wantPath, nil }
 
-	c := NewConfigPathCmd(resolvePath)
+	c := NewConfigPathCmd(&Globals{ResolvePath: resolvePath})
 	c.SetOut(new(bytes.Buffer))
 	c.SetErr(new(bytes.Buffer))
 
@@ -37,9 +44,9 @@ func TestConfigShowCmd_HumanOutput_MasksAPIKey(t *testing.T) {
 	}
 
 	resolvePath := func() (string, error) { return path, nil }
-	outputJSON := func() bool { return false }
+	outputFormat := func() string { return "table" }
 
-	c := NewConfigShowCmd(resolvePath, outputJSON)
+	c := NewConfigShowCmd(&Globals{ResolvePath: resolvePath, Output: outputFormat})
 	buf := new(bytes.Buffer)
 	c.SetOut(buf)
 	c.SetErr(new(bytes.Buffer))
@@ -57,3 +64,200 @@ func TestConfigShowCmd_HumanOutput_MasksAPIKey(t *testing.T) {
 		t.Error("human output must mask api_key with ***")
 	}
 }
+
+func TestConfigProfileCmds_AddUseRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	g := &Globals{
+		ResolvePath: func() (string, error) { return path, nil },
+		Output:      func() string { return "table" },
+	}
+	run := func(c *cobra.Command, args ...string) error {
+		c.SetArgs(args)
+		c.SetOut(new(bytes.Buffer))
+		c.SetErr(new(bytes.Buffer))
+		return c.Execute()
+	}
+
+	if err := run(NewConfigAddProfileCmd(g), "work", "--api-key", "work-key"); err != nil {
+		t.Fatalf("add-profile: %v", err)
+	}
+	if err := run(NewConfigAddProfileCmd(g), "work"); err == nil {
+		t.Error("add-profile of existing profile should fail")
+	}
+	if err := run(NewConfigAddProfileCmd(g), "../.."); err == nil {
+		t.Error("add-profile with a path as name should fail")
+	}
+	if err := run(NewConfigUseProfileCmd(g), "work"); err != nil {
+		t.Fatalf("use-profile: %v", err)
+	}
+	if err := run(NewConfigUseProfileCmd(g), "missing"); err == nil {
+		t.Error("use-profile of missing profile should fail")
+	}
+	if err := run(NewConfigUseProfileCmd(g), "../work"); clierrors.ExitCode(err) != clierrors.ExitCodeConfig {
+		t.Errorf("use-profile with a path as name: exit code %d, want %d (err %v)", clierrors.ExitCode(err), clierrors.ExitCodeConfig, err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CurrentProfile != "work" || cfg.Profiles["work"].APIKey != "work-key" {
+		t.Errorf("cfg after add/use = %+v", cfg)
+	}
+
+	if err := run(NewConfigRemoveProfileCmd(g), "work"); err != nil {
+		t.Fatalf("remove-profile: %v", err)
+	}
+	cfg, err = config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CurrentProfile != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("cfg after remove = %+v", cfg)
+	}
+}
+
+func TestConfigProfileCmds_JSONOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	g := &Globals{
+		ResolvePath: func() (string, error) { return path, nil },
+		Output:      func() string { return "json" },
+	}
+	for _, tc := range []struct {
+		cmd  *cobra.Command
+		args []string
+		want map[string]string
+	}{
+		{NewConfigAddProfileCmd(g), []string{"work", "--api-key", "work-key"}, map[string]string{"added": "work"}},
+		{NewConfigUseProfileCmd(g), []string{"work"}, map[string]string{"current_profile": "work"}},
+		{NewConfigRemoveProfileCmd(g), []string{"work"}, map[string]string{"removed": "work"}},
+	} {
+		buf := new(bytes.Buffer)
+		tc.cmd.SetArgs(tc.args)
+		tc.cmd.SetOut(buf)
+		if err := tc.cmd.Execute(); err != nil {
+			t.Fatalf("%s: %v", tc.cmd.Name(), err)
+		}
+		var got map[string]string
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("%s: output is not JSON: %v\n%s", tc.cmd.Name(), err, buf.String())
+		}
+		for k, v := range tc.want {
+			if got[k] != v {
+				t.Errorf("%s: %s = %q, want %q", tc.cmd.Name(), k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestConfigShowCmd_JSONOutput_ReportsSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("base_url: https://x.com\napi_key: secret123\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvBaseURL, "")
+	t.Setenv(config.EnvAPIKey, "env-secret")
+
+	c := NewConfigShowCmd(&Globals{
+		ResolvePath: func() (string, error) { return path, nil },
+		Output:      func() string { return "json" },
+	})
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(new(bytes.Buffer))
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	var got struct {
+		BaseURL struct{ Value, Source string } `json:"base_url"`
+		APIKey  struct{ Value, Source string } `json:"api_key"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	if got.BaseURL.Value != "https://x.com" || got.BaseURL.Source != "file" {
+		t.Errorf("base_url = %+v, want file value", got.BaseURL)
+	}
+	if got.APIKey.Value != "***" || got.APIKey.Source != "env" {
+		t.Errorf("api_key = %+v, want masked env value", got.APIKey)
+	}
+}
+
+func TestConfigSetCredentialStore_MovesKeysOutOfFile(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv(config.EnvAPIKey, "")
+	t.Setenv(config.EnvProfile, "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("api_key: secret123\nprofiles:\n  work:\n    api_key: work-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	g := &Globals{
+		ResolvePath: func() (string, error) { return path, nil },
+		Profile:     func() string { return "work" },
+		Output:      func() string { return "table" },
+	}
+
+	c := NewConfigSetCredentialStoreCmd(g)
+	c.SetArgs([]string{"keyring"})
+	c.SetOut(new(bytes.Buffer))
+	if err := c.Execute(); err != nil {
+		t.Fatalf("set-credential-store: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret123") || strings.Contains(string(data), "work-key") {
+		t.Errorf("config still contains plaintext keys:\n%s", data)
+	}
+	if got, err := keyring.Get(secret.Service, "default@https://ru.yougile.com"); err != nil || got != "secret123" {
+		t.Errorf("keyring default = %q, %v", got, err)
+	}
+
+	cfg, s, err := loadSettings(g)
+	if err != nil {
+		t.Fatalf("loadSettings: %v", err)
+	}
+	if err := resolveStoredKey(cfg, &s); err != nil {
+		t.Fatalf("resolveStoredKey: %v", err)
+	}
+	if s.APIKey != "work-key" || s.APIKeySource != config.SourceStore {
+		t.Errorf("api key = %q (%s), want work-key from store", s.APIKey, s.APIKeySource)
+	}
+}
+
+func TestKeyringKeys_SameProfileOtherServer_DoNotCollide(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv(config.EnvAPIKey, "")
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvBaseURL, "")
+	keys := map[string]string{"https://ru.yougile.com": "ru-key", "https://yougile.example.com": "onprem-key"}
+	paths := map[string]string{}
+	for baseURL, key := range keys {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		cfg := &config.Config{BaseURL: baseURL, CredentialStore: secret.Keyring}
+		if err := setProfileKey(cfg, path, config.DefaultProfile, config.Profile{BaseURL: baseURL}, key); err != nil {
+			t.Fatalf("setProfileKey: %v", err)
+		}
+		if err := config.Save(path, cfg); err != nil {
+			t.Fatal(err)
+		}
+		paths[baseURL] = path
+	}
+	for baseURL, want := range keys {
+		path := paths[baseURL]
+		cfg, s, err := loadSettings(&Globals{ResolvePath: func() (string, error) { return path, nil }})
+		if err != nil {
+			t.Fatalf("loadSettings: %v", err)
+		}
+		if err := resolveStoredKey(cfg, &s); err != nil {
+			t.Fatalf("resolveStoredKey: %v", err)
+		}
+		if s.APIKey != want {
+			t.Errorf("%s: api key = %q, want %q", baseURL, s.APIKey, want)
+		}
+	}
+}
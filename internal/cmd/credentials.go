@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/angolovin/yougile-cli/internal/config"
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/internal/secret"
+)
+
+// openCredentialStore opens the credential_store backend of cfg (a nil Store
+// means keys are kept in the config file at path).
+func openCredentialStore(cfg *config.Config, path string) (secret.Store, error) {
+	return openStore(cfg.CredentialStore, path)
+}
+
+func openStore(backend, path string) (secret.Store, error) {
+	store, err := secret.Open(backend, secret.Options{
+		FilePath:   config.CredentialsPath(path),
+		Passphrase: credentialsPassphrase,
+	})
+	if err != nil {
+		return nil, clierrors.NewConfigError(err)
+	}
+	return store, nil
+}
+
+// credentialsPassphrase returns $YOUGILE_PASSPHRASE or prompts for it on the terminal.
+func credentialsPassphrase() (string, error) {
+	if pass := os.Getenv(config.EnvPassphrase); pass != "" {
+		return pass, nil
+	}
+	pass, err := promptSecret("Credentials passphrase: ")
+	if err != nil {
+		return "", clierrors.NewConfigError(fmt.Errorf("credentials passphrase: %w (set %s)", err, config.EnvPassphrase))
+	}
+	return pass, nil
+}
+
+// storeAccount is the credential store entry of the named profile, e.g.
+// "default@https://ru.yougile.com". The OS keyring is shared by every config
+// file, so the base URL keeps same-named profiles for different servers apart.
+func storeAccount(name string, p config.Profile) string {
+	baseURL := p.BaseURL
+	if baseURL == "" {
+		baseURL = config.DefaultBaseURL()
+	}
+	return name + "@" + strings.TrimRight(baseURL, "/")
+}
+
+// resolveStoredKey fills in s.APIKey from the credential store when neither
+// the environment nor the config file supplied one.
+func resolveStoredKey(cfg *config.Config, s *config.Settings) error {
+	if s.APIKey != "" || cfg == nil {
+		return nil
+	}
+	store, err := openCredentialStore(cfg, s.ConfigPath)
+	if err != nil || store == nil {
+		return err
+	}
+	p, _, _ := cfg.Profile(s.Profile)
+	key, err := store.Get(storeAccount(s.Profile, p))
+	if errors.Is(err, secret.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return clierrors.NewConfigError(fmt.Errorf("read api key from %s store: %w", cfg.CredentialStore, err))
+	}
+	s.APIKey, s.APIKeySource = key, config.SourceStore
+	return nil
+}
+
+// setProfileKey saves key for the named profile: into the credential store if
+// one is configured (leaving api_key empty in the file), otherwise into p.
+// The caller saves cfg.
+func setProfileKey(cfg *config.Config, path, name string, p config.Profile, key string) error {
+	store, err := openCredentialStore(cfg, path)
+	if err != nil {
+		return err
+	}
+	p.APIKey = key
+	if store != nil {
+		if err := store.Set(storeAccount(name, p), key); err != nil {
+			return fmt.Errorf("save api key to %s store: %w", cfg.CredentialStore, err)
+		}
+		p.APIKey = ""
+	}
+	cfg.SetProfile(name, p)
+	return nil
+}
+
+// deleteProfileKey removes the key of profile p, named name, from the
+// credential store, if any.
+func deleteProfileKey(cfg *config.Config, path, name string, p config.Profile) error {
+	store, err := openCredentialStore(cfg, path)
+	if err != nil || store == nil {
+		return err
+	}
+	if err := store.Delete(storeAccount(name, p)); err != nil && !errors.Is(err, secret.ErrNotFound) {
+		return fmt.Errorf("delete api key from %s store: %w", cfg.CredentialStore, err)
+	}
+	return nil
+}
@@ -24,7 +24,7 @@ type createContactPersonBody struct {
 }
 
 // NewCrmContactPersonsCreateCmd returns the "crm contact-persons create" command.
-func NewCrmContactPersonsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewCrmContactPersonsCreateCmd(g *Globals) *cobra.Command {
 	var title, projectID, email, phone, address, position, additionalPhone string
 	c := &cobra.Command{
 		Use:   "create",
@@ -33,7 +33,7 @@ func NewCrmContactPersonsCreateCmd(resolvePath func() (string, error), outputJSO
 			if title == "" || projectID == "" {
 				return fmt.Errorf("title and project-id are required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -72,17 +72,13 @@ func NewCrmContactPersonsCreateCmd(resolvePath func() (string, error), outputJSO
 				return fmt.Errorf("create contact person: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create contact person: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create contact person", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Contact person created: id=%s\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Contact person created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "contact name/title")
@@ -98,7 +94,7 @@ func NewCrmContactPersonsCreateCmd(resolvePath func() (string, error), outputJSO
 }
 
 // NewCrmContactsByExternalIdCmd returns the "crm contacts by-external-id" command.
-func NewCrmContactsByExternalIdCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewCrmContactsByExternalIdCmd(g *Globals) *cobra.Command {
 	var provider, chatID string
 	c := &cobra.Command{
 		Use:   "by-external-id",
@@ -107,7 +103,7 @@ func NewCrmContactsByExternalIdCmd(resolvePath func() (string, error), outputJSO
 			if provider == "" || chatID == "" {
 				return fmt.Errorf("provider and chat-id are required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -120,17 +116,10 @@ func NewCrmContactsByExternalIdCmd(resolvePath func() (string, error), outputJSO
 				return fmt.Errorf("find contact by external id: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("find contact by external id: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("find contact by external id", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			if resp.JSON200 == nil {
-				_, err = fmt.Fprintln(out, "No contact found")
-				return err
-			}
-			return output.PrintJSON(out, resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200, Message: "No contact found"})
 		},
 	}
 	c.Flags().StringVar(&provider, "provider", "", "external integration provider")
@@ -141,16 +130,16 @@ func NewCrmContactsByExternalIdCmd(resolvePath func() (string, error), outputJSO
 }
 
 // NewCrmCmd returns the "crm" parent command.
-func NewCrmCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewCrmCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "crm",
 		Short: "CRM contacts and contact persons",
 	}
 	contactPersons := &cobra.Command{Use: "contact-persons", Short: "Contact persons"}
-	contactPersons.AddCommand(NewCrmContactPersonsCreateCmd(resolvePath, outputJSON))
+	contactPersons.AddCommand(NewCrmContactPersonsCreateCmd(g))
 	c.AddCommand(contactPersons)
 	contacts := &cobra.Command{Use: "contacts", Short: "Contacts"}
-	contacts.AddCommand(NewCrmContactsByExternalIdCmd(resolvePath, outputJSON))
+	contacts.AddCommand(NewCrmContactsByExternalIdCmd(g))
 	c.AddCommand(contacts)
 	return c
 }
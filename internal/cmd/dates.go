@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date flags (--deadline, --start, --deadline-before, ...) accept, in the
+// local time zone (timezone in the config overrides it):
+//
+//	2026-11-01, 01.11.2026                 a date
+//	"2026-11-01 18:00", 2026-11-01T18:00   a date and time
+//	2026-11-01T18:00:00+03:00              RFC 3339 / ISO 8601 with a zone
+//	now, today, tomorrow, yesterday        optionally followed by a time: "tomorrow 18:00"
+//	+3d, -1d, +2w, +4h, +30min             relative to now; d and w keep it a date
+//	friday, fri, next friday               the first such day after today, optionally with a time
+//
+// Values with a time of day set withTime, so YouGile shows the time on the card.
+
+var (
+	dateLayouts = []struct {
+		layout   string
+		withTime bool
+	}{
+		{"2006-01-02", false},
+		{"02.01.2006", false},
+		{"2006-01-02 15:04", true},
+		{"2006-01-02T15:04", true},
+		{"2006-01-02 15:04:05", true},
+		{"2006-01-02T15:04:05", true},
+		{"02.01.2006 15:04", true},
+		{time.RFC3339, true},
+	}
+	relativeDate = regexp.MustCompile(`^([+-])(\d+)\s*(min|m|h|d|w)$`)
+	clockTime    = regexp.MustCompile(`^(\d{1,2}):(\d{2})$`)
+	weekdays     = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+		"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+)
+
+// parseDate parses a date flag value (see above).
+func parseDate(s string) (time.Time, error) {
+	t, _, err := parseDateTime(s)
+	return t, err
+}
+
+// parseDateTime is parseDate that also reports whether s has a time of day.
+func parseDateTime(s string) (time.Time, bool, error) {
+	return parseDateAt(s, time.Now())
+}
+
+// parseDateAt parses s relative to now.
+func parseDateAt(s string, now time.Time) (time.Time, bool, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range dateLayouts {
+		if t, err := time.ParseInLocation(l.layout, s, now.Location()); err == nil {
+			return t, l.withTime, nil
+		}
+	}
+
+	lower := strings.ToLower(s)
+	if m := relativeDate.FindStringSubmatch(lower); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		switch m[3] {
+		case "min", "m":
+			return now.Add(time.Duration(n) * time.Minute), true, nil
+		case "h":
+			return now.Add(time.Duration(n) * time.Hour), true, nil
+		case "d":
+			return startOfDay(now).AddDate(0, 0, n), false, nil
+		case "w":
+			return startOfDay(now).AddDate(0, 0, 7*n), false, nil
+		}
+	}
+
+	fields := strings.Fields(lower)
+	if len(fields) > 0 && fields[0] == "next" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return time.Time{}, false, invalidDate(s)
+	}
+	var day time.Time
+	switch fields[0] {
+	case "now":
+		if len(fields) == 1 {
+			return now, true, nil
+		}
+		return time.Time{}, false, invalidDate(s)
+	case "today":
+		day = startOfDay(now)
+	case "tomorrow":
+		day = startOfDay(now).AddDate(0, 0, 1)
+	case "yesterday":
+		day = startOfDay(now).AddDate(0, 0, -1)
+	default:
+		wd, ok := weekdays[fields[0]]
+		if !ok {
+			return time.Time{}, false, invalidDate(s)
+		}
+		ahead := (int(wd) - int(now.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		day = startOfDay(now).AddDate(0, 0, ahead)
+	}
+	if len(fields) == 1 {
+		return day, false, nil
+	}
+	m := clockTime.FindStringSubmatch(fields[1])
+	if m == nil {
+		return time.Time{}, false, invalidDate(s)
+	}
+	h, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	if h > 23 || minute > 59 {
+		return time.Time{}, false, invalidDate(s)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), h, minute, 0, 0, day.Location()), true, nil
+}
+
+func invalidDate(s string) error {
+	return fmt.Errorf("invalid date %q (want e.g. 2026-11-01, \"2026-11-01 18:00\", tomorrow, +3d or \"next friday\")", s)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
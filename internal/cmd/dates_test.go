@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateAt(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2026, 10, 14, 9, 30, 0, 0, loc)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, loc) }
+	at := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, loc) }
+
+	for in, want := range map[string]struct {
+		t        time.Time
+		withTime bool
+	}{
+		"2026-11-01":                {time.Date(2026, 11, 1, 0, 0, 0, 0, loc), false},
+		"01.11.2026":                {time.Date(2026, 11, 1, 0, 0, 0, 0, loc), false},
+		"2026-11-01 18:00":          {time.Date(2026, 11, 1, 18, 0, 0, 0, loc), true},
+		"2026-11-01T18:00:00+03:00": {time.Date(2026, 11, 1, 18, 0, 0, 0, loc), true},
+		"today":                     {day(14), false},
+		"Tomorrow 18:00":            {at(15, 18, 0), true},
+		"+3d":                       {day(17), false},
+		"-1d":                       {day(13), false},
+		"+2w":                       {day(28), false},
+		"+4h":                       {at(14, 13, 30), true},
+		"friday":                    {day(16), false},
+		"next friday 10:00":         {at(16, 10, 0), true},
+		"wed":                       {day(21), false},
+	} {
+		got, withTime, err := parseDateAt(in, now)
+		if err != nil || !got.Equal(want.t) || withTime != want.withTime {
+			t.Errorf("parseDateAt(%q) = %v, %v, %v; want %v, %v", in, got, withTime, err, want.t, want.withTime)
+		}
+	}
+	for _, in := range []string{"", "soon", "tomorrow 25:00", "next", "now 10:00", "2026-13-01"} {
+		if _, _, err := parseDateAt(in, now); err == nil {
+			t.Errorf("parseDateAt(%q): want error", in)
+		}
+	}
+}
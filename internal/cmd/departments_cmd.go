@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -11,26 +10,21 @@ import (
 )
 
 // NewDepartmentsListCmd returns the "departments list" command.
-func NewDepartmentsListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewDepartmentsListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var title, parentID string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List departments",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
 
-			params := &client.DepartmentControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.DepartmentControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
@@ -38,42 +32,45 @@ func NewDepartmentsListCmd(resolvePath func() (string, error), outputJSON func()
 				params.ParentId = strPtr(parentID)
 			}
 
-			resp, err := api.DepartmentControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list departments: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list departments: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list departments: empty response")
-			}
-
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Title", "ParentId"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, d := range resp.JSON200.Content {
-				pID := ""
-				if d.ParentId != nil {
-					pID = *d.ParentId
+			fetch := func(ctx context.Context, limit, offset int) ([]client.DepartmentListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.DepartmentControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list departments: %w", err)
 				}
-				rows = append(rows, []string{d.Id, d.Title, pID})
-			}
-			return output.PrintTable(out, headers, rows)
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list departments", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list departments: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			n := g.lookupNames(context.Background(), api, s)
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(departments []client.DepartmentListDtoBase, paging client.PagingMetadata) output.Result {
+				table := &output.Table{Columns: []output.Column{{Header: "ID"}, {Header: "Title"}, {Header: "Parent", Field: "parentId"}}}
+				for _, d := range departments {
+					parent := ""
+					if d.ParentId != nil && *d.ParentId != "" {
+						parent = n.department(*d.ParentId)
+					}
+					table.Rows = append(table.Rows, []string{d.Id, d.Title, parent})
+				}
+				return output.Result{Value: client.DepartmentListDto{Content: departments, Paging: paging}, Items: departments, Table: table}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
 	c.Flags().StringVar(&parentID, "parent-id", "", "filter by parent department ID")
 	return c
 }
 
 // NewDepartmentsCreateCmd returns the "departments create" command.
-func NewDepartmentsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewDepartmentsCreateCmd(g *Globals) *cobra.Command {
 	var title, parentID string
 	c := &cobra.Command{
 		Use:   "create",
@@ -82,7 +79,7 @@ func NewDepartmentsCreateCmd(resolvePath func() (string, error), outputJSON func
 			if title == "" {
 				return fmt.Errorf("title is required (--title)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -95,17 +92,13 @@ func NewDepartmentsCreateCmd(resolvePath func() (string, error), outputJSON func
 				return fmt.Errorf("create department: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create department: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create department", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Department created: id=%s\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Department created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "department title")
@@ -115,14 +108,14 @@ func NewDepartmentsCreateCmd(resolvePath func() (string, error), outputJSON func
 }
 
 // NewDepartmentsUpdateCmd returns the "departments update" command.
-func NewDepartmentsUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewDepartmentsUpdateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a department",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -136,14 +129,10 @@ func NewDepartmentsUpdateCmd(resolvePath func() (string, error), outputJSON func
 				return fmt.Errorf("update department: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update department: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update department", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Department updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Department updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "department title")
@@ -151,13 +140,13 @@ func NewDepartmentsUpdateCmd(resolvePath func() (string, error), outputJSON func
 }
 
 // NewDepartmentGetCmd returns the "departments get" command.
-func NewDepartmentGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewDepartmentGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
 		Short: "Get department by ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -168,32 +157,27 @@ func NewDepartmentGetCmd(resolvePath func() (string, error), outputJSON func() b
 				return fmt.Errorf("get department: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get department: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get department", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get department: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewDepartmentsCmd returns the "departments" parent command.
-func NewDepartmentsCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewDepartmentsCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "departments",
 		Short: "Manage departments",
 	}
-	c.AddCommand(NewDepartmentsListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewDepartmentGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewDepartmentsCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewDepartmentsUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewDepartmentsListCmd(g))
+	c.AddCommand(NewDepartmentGetCmd(g))
+	c.AddCommand(NewDepartmentsCreateCmd(g))
+	c.AddCommand(NewDepartmentsUpdateCmd(g))
 	return c
 }
@@ -14,13 +14,13 @@ import (
 )
 
 // NewFilesUploadCmd returns the "files upload" command.
-func NewFilesUploadCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewFilesUploadCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "upload [file]",
 		Short: "Upload a file",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -51,27 +51,23 @@ func NewFilesUploadCmd(resolvePath func() (string, error), outputJSON func() boo
 				return fmt.Errorf("upload: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("upload: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("upload", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("upload: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "URL: %s\n", resp.JSON200.FullUrl)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("URL: %s", resp.JSON200.FullUrl)})
 		},
 	}
 }
 
 // NewFilesCmd returns the "files" parent command.
-func NewFilesCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewFilesCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "files",
 		Short: "File operations",
 	}
-	c.AddCommand(NewFilesUploadCmd(resolvePath, outputJSON))
+	c.AddCommand(NewFilesUploadCmd(g))
 	return c
 }
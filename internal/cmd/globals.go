@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"golang.org/x/term"
+)
+
+// Globals gives subcommands access to the root command's persistent flags.
+// Fields are funcs so values are read after flag parsing.
+type Globals struct {
+	// ResolvePath returns the config file path (-c/--config or the default).
+	ResolvePath func() (string, error)
+	// Profile returns the --profile value; empty means current_profile from config.
+	Profile func() string
+	// Output returns the -o/--output value; empty means table.
+	Output func() string
+	// View returns the --columns and --sort-by values.
+	View func() output.View
+	// Layout returns the --no-headers and --wrap values; MaxWidth is set by print.
+	Layout func() output.TableOptions
+	// RawIDs returns the --raw-ids value: show IDs instead of resolving names.
+	RawIDs func() bool
+	// NoCache returns the --no-cache value: fetch lists instead of using the cache.
+	NoCache func() bool
+}
+
+// profile returns the --profile value, or "" if the root did not provide one.
+func (g *Globals) profile() string {
+	if g.Profile == nil {
+		return ""
+	}
+	return g.Profile()
+}
+
+// rawIDs returns the --raw-ids value, or false if the root did not provide one.
+func (g *Globals) rawIDs() bool {
+	return g.RawIDs != nil && g.RawIDs()
+}
+
+// noCache returns the --no-cache value, or false if the root did not provide one.
+func (g *Globals) noCache() bool {
+	return g.NoCache != nil && g.NoCache()
+}
+
+// format returns the -o/--output format, or table if the root did not provide one.
+func (g *Globals) format() output.Format {
+	if g.Output == nil {
+		return output.FormatTable
+	}
+	return output.Format(g.Output())
+}
+
+// print writes r to w in the -o/--output format, with the columns and
+// row order chosen by --columns and --sort-by. Tables written to a terminal
+// are fitted to its width.
+func (g *Globals) print(w io.Writer, r output.Result) error {
+	return g.printPage(w, r, true)
+}
+
+// streamsPages reports whether a list fetched with --all can be printed page
+// by page: ndjson, csv and tsv write each item on its own line, while the
+// other formats and --sort-by need the whole list.
+func (g *Globals) streamsPages() bool {
+	switch g.format() {
+	case output.FormatNDJSON, output.FormatCSV, output.FormatTSV:
+		return g.View == nil || g.View().SortBy == ""
+	}
+	return false
+}
+
+// printPage is print for one page of a streamed list; csv and tsv headers
+// are written before the first page only.
+func (g *Globals) printPage(w io.Writer, r output.Result, first bool) error {
+	var opts output.TableOptions
+	if g.Layout != nil {
+		opts = g.Layout()
+	}
+	opts.NoHeaders = opts.NoHeaders || !first
+	opts.MaxWidth = terminalWidth(w)
+	p, err := output.NewPrinter(g.format(), opts)
+	if err != nil {
+		return err
+	}
+	if g.View != nil {
+		if r, err = g.View().Apply(r); err != nil {
+			return err
+		}
+	}
+	return p.Print(w, r)
+}
+
+// terminalWidth returns the width tables written to w must fit: $COLUMNS or
+// the terminal size when w is a terminal, 0 (no limit) when it is a pipe or file.
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if n, _, err := term.GetSize(int(f.Fd())); err == nil {
+		return n
+	}
+	return 0
+}
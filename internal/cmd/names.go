@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/angolovin/yougile-cli/internal/cache"
+	"github.com/angolovin/yougile-cli/internal/config"
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// cacheKinds are the collections kept in the cache and how long each is used
+// before it is refetched (unless cache_ttl overrides it). Projects, boards and
+// columns change during the day; people and stickers rarely do.
+var cacheKinds = []struct {
+	name string
+	ttl  time.Duration
+}{
+	{"projects", 10 * time.Minute},
+	{"boards", 10 * time.Minute},
+	{"columns", 10 * time.Minute},
+	{"users", time.Hour},
+	{"departments", time.Hour},
+	{"string-stickers", time.Hour},
+	{"sprint-stickers", time.Hour},
+}
+
+// lookupPageSize is the page size used to load whole collections.
+const lookupPageSize = 1000
+
+// names resolves user, column, board, project, department and sticker IDs to
+// names for human output. Each collection is loaded once per command with a
+// single search (all pages) and kept in the on-disk cache (see cacheKinds).
+// IDs that cannot be resolved (deleted objects, no access, API errors) are
+// shown as is; resolving a reference instead reports the fetch error. A nil
+// *names shows every ID as is (--raw-ids).
+type names struct {
+	ctx   context.Context
+	api   *client.ClientWithResponses
+	cache *cache.Cache  // nil: always fetch (--no-cache)
+	ttl   time.Duration // cache_ttl; 0 means the cacheKinds defaults
+	fresh bool          // ignore cached entries (see refresh)
+	email string        // the profile's email, for the user reference "me"
+
+	users       map[string]client.UserListDtoBase
+	columns     map[string]client.ColumnListDtoBase
+	boards      map[string]client.BoardListDtoBase
+	projects    map[string]client.ProjectListDtoBase
+	departments map[string]client.DepartmentListDtoBase
+	stickers    map[string]*stickerNames
+	errs        map[string]error // fetch errors by kind, once loaded
+}
+
+// stickerNames is a sticker's name and its state names by state ID.
+type stickerNames struct {
+	Name   string
+	States map[string]string
+}
+
+// newNames returns a lookup that caches collections fetched with api under
+// the profile of s, or always fetches them with --no-cache.
+func (g *Globals) newNames(ctx context.Context, api *client.ClientWithResponses, s config.Settings) *names {
+	n := &names{ctx: ctx, api: api, email: s.Email, ttl: s.CacheTTL}
+	if !g.noCache() {
+		n.cache = profileCache(s)
+	}
+	return n
+}
+
+// profileCache returns the cache of the profile of s, or nil if there is no
+// user cache dir (or the profile name is not a valid directory name).
+func profileCache(s config.Settings) *cache.Cache {
+	root, err := cache.DefaultDir()
+	if err != nil {
+		return nil
+	}
+	c, err := cache.New(root, s.Profile, cache.Owner(s.BaseURL, s.APIKey))
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
+// kindTTL returns how long the kind's cache entry is used: override
+// (cache_ttl) if set, else the cacheKinds default. Task keys never change
+// and always keep taskKeysTTL.
+func kindTTL(kind string, override time.Duration) time.Duration {
+	if kind == "task-keys" {
+		return taskKeysTTL
+	}
+	if override > 0 {
+		return override
+	}
+	for _, k := range cacheKinds {
+		if k.name == kind {
+			return k.ttl
+		}
+	}
+	return 10 * time.Minute
+}
+
+// lookupNames returns the name lookup for human output, or nil when IDs must
+// be shown as is: with --raw-ids, and for formats that print the API objects.
+func (g *Globals) lookupNames(ctx context.Context, api *client.ClientWithResponses, s config.Settings) *names {
+	if g.rawIDs() {
+		return nil
+	}
+	switch g.format() {
+	case output.FormatTable, output.FormatWide, output.FormatCSV, output.FormatTSV:
+		return g.newNames(ctx, api, s)
+	}
+	return nil
+}
+
+// cachedList returns the kind's collection from the cache or, when it is
+// missing or stale, from every page of fetch, and caches it. A fetch error
+// is kept in n.errs for the resolvers and returned.
+func cachedList[T any](n *names, kind string, fetch pageFetcher[T]) ([]T, error) {
+	var items []T
+	if n.cache != nil && !n.fresh {
+		if ok, _ := n.cache.Load(kind, kindTTL(kind, n.ttl), &items); ok {
+			return items, nil
+		}
+	}
+	items, err := fetchList(n, kind, fetch)
+	if err != nil {
+		if n.errs == nil {
+			n.errs = map[string]error{}
+		}
+		n.errs[kind] = err
+		return nil, err
+	}
+	return items, nil
+}
+
+// fetchList fetches every page of the kind's collection and caches it. Cache
+// write errors are ignored: a read-only cache dir only costs speed.
+func fetchList[T any](n *names, kind string, fetch pageFetcher[T]) ([]T, error) {
+	items, _, err := collectPages(n.ctx, lookupPageSize, 0, true, fetch)
+	if err != nil {
+		return nil, err
+	}
+	if n.cache != nil {
+		_ = n.cache.Save(kind, items)
+	}
+	return items, nil
+}
+
+// refreshKind refetches the kind's collection into the cache and returns
+// its size.
+func (n *names) refreshKind(kind string) (int, error) {
+	switch kind {
+	case "projects":
+		items, err := fetchList(n, kind, n.fetchProjects)
+		return len(items), err
+	case "boards":
+		items, err := fetchList(n, kind, n.fetchBoards)
+		return len(items), err
+	case "columns":
+		items, err := fetchList(n, kind, n.fetchColumns)
+		return len(items), err
+	case "users":
+		items, err := fetchList(n, kind, n.fetchUsers)
+		return len(items), err
+	case "departments":
+		items, err := fetchList(n, kind, n.fetchDepartments)
+		return len(items), err
+	case "string-stickers":
+		items, err := fetchList(n, kind, n.fetchStringStickers)
+		return len(items), err
+	case "sprint-stickers":
+		items, err := fetchList(n, kind, n.fetchSprintStickers)
+		return len(items), err
+	}
+	return 0, fmt.Errorf("unknown cache kind %q", kind)
+}
+
+// user returns the user's real name (or email), or id.
+func (n *names) user(id string) string {
+	if n == nil {
+		return id
+	}
+	_ = n.loadUsers()
+	if u, ok := n.users[id]; ok {
+		return orID(orID(u.RealName, u.Email), id)
+	}
+	return id
+}
+
+// loadUsers loads the users once and returns the error of that fetch.
+func (n *names) loadUsers() error {
+	if n.users == nil {
+		n.users = map[string]client.UserListDtoBase{}
+		users, _ := cachedList(n, "users", n.fetchUsers)
+		for _, u := range users {
+			n.users[u.Id] = u
+		}
+	}
+	return n.errs["users"]
+}
+
+// userList returns the names of the users ids.
+func (n *names) userList(ids []string) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = n.user(id)
+	}
+	return out
+}
+
+func (n *names) column(id string) (client.ColumnListDtoBase, bool) {
+	_ = n.loadColumns()
+	c, ok := n.columns[id]
+	return c, ok
+}
+
+// loadColumns loads the columns once and returns the error of that fetch.
+func (n *names) loadColumns() error {
+	if n.columns == nil {
+		n.columns = map[string]client.ColumnListDtoBase{}
+		columns, _ := cachedList(n, "columns", n.fetchColumns)
+		for _, c := range columns {
+			n.columns[c.Id] = c
+		}
+	}
+	return n.errs["columns"]
+}
+
+func (n *names) boardDto(id string) (client.BoardListDtoBase, bool) {
+	_ = n.loadBoards()
+	b, ok := n.boards[id]
+	return b, ok
+}
+
+// loadBoards loads the boards once and returns the error of that fetch.
+func (n *names) loadBoards() error {
+	if n.boards == nil {
+		n.boards = map[string]client.BoardListDtoBase{}
+		boards, _ := cachedList(n, "boards", n.fetchBoards)
+		for _, b := range boards {
+			n.boards[b.Id] = b
+		}
+	}
+	return n.errs["boards"]
+}
+
+// columnTitle returns "Board / Column", or id.
+func (n *names) columnTitle(id string) string {
+	if n == nil || id == "" {
+		return id
+	}
+	c, ok := n.column(id)
+	if !ok {
+		return id
+	}
+	return n.board(c.BoardId) + " / " + c.Title
+}
+
+// columnPath returns the project, board and column titles of a column.
+func (n *names) columnPath(id string) []string {
+	if n == nil {
+		return []string{id}
+	}
+	c, ok := n.column(id)
+	if !ok {
+		return []string{id}
+	}
+	b, ok := n.boardDto(c.BoardId)
+	if !ok {
+		return []string{c.BoardId, c.Title}
+	}
+	return []string{n.project(b.ProjectId), b.Title, c.Title}
+}
+
+// board returns the board title, or id.
+func (n *names) board(id string) string {
+	if n == nil {
+		return id
+	}
+	if b, ok := n.boardDto(id); ok {
+		return b.Title
+	}
+	return id
+}
+
+// project returns the project title, or id.
+func (n *names) project(id string) string {
+	if n == nil {
+		return id
+	}
+	_ = n.loadProjects()
+	if p, ok := n.projects[id]; ok {
+		return p.Title
+	}
+	return id
+}
+
+// loadProjects loads the projects once and returns the error of that fetch.
+func (n *names) loadProjects() error {
+	if n.projects == nil {
+		n.projects = map[string]client.ProjectListDtoBase{}
+		projects, _ := cachedList(n, "projects", n.fetchProjects)
+		for _, p := range projects {
+			n.projects[p.Id] = p
+		}
+	}
+	return n.errs["projects"]
+}
+
+// department returns the department title, or id.
+func (n *names) department(id string) string {
+	if n == nil {
+		return id
+	}
+	if n.departments == nil {
+		n.departments = map[string]client.DepartmentListDtoBase{}
+		departments, _ := cachedList(n, "departments", n.fetchDepartments)
+		for _, d := range departments {
+			n.departments[d.Id] = d
+		}
+	}
+	if d, ok := n.departments[id]; ok {
+		return d.Title
+	}
+	return id
+}
+
+// sticker returns the sticker's name and the name of its state value;
+// unknown stickers and free-form values are returned as is.
+func (n *names) sticker(id string, value interface{}) (string, string) {
+	v := fmt.Sprint(value)
+	if n == nil {
+		return id, v
+	}
+	_ = n.loadStickers()
+	s, ok := n.stickers[id]
+	if !ok {
+		return id, v
+	}
+	if state, ok := s.States[v]; ok {
+		v = state
+	}
+	return s.Name, v
+}
+
+// loadStickers loads the string and sprint stickers once and returns the
+// error of those fetches.
+func (n *names) loadStickers() error {
+	if n.stickers == nil {
+		n.stickers = map[string]*stickerNames{}
+		stringStickers, _ := cachedList(n, "string-stickers", n.fetchStringStickers)
+		for _, s := range stringStickers {
+			sn := &stickerNames{Name: s.Name, States: map[string]string{}}
+			if s.States != nil {
+				for _, st := range *s.States {
+					sn.States[st.Id] = st.Name
+				}
+			}
+			n.stickers[s.Id] = sn
+		}
+		sprintStickers, _ := cachedList(n, "sprint-stickers", n.fetchSprintStickers)
+		for _, s := range sprintStickers {
+			sn := &stickerNames{Name: s.Name, States: map[string]string{}}
+			if s.States != nil {
+				for _, st := range *s.States {
+					sn.States[st.Id] = st.Name
+				}
+			}
+			n.stickers[s.Id] = sn
+		}
+	}
+	return errors.Join(n.errs["string-stickers"], n.errs["sprint-stickers"])
+}
+
+// orID returns name, or id when name is empty.
+func orID(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return name
+}
+
+func (n *names) fetchUsers(ctx context.Context, limit, offset int) ([]client.UserListDtoBase, client.PagingMetadata, error) {
+	p := client.UserControllerSearchParams{}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.UserControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list users", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchColumns(ctx context.Context, limit, offset int) ([]client.ColumnListDtoBase, client.PagingMetadata, error) {
+	p := client.ColumnControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.ColumnControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list columns", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchBoards(ctx context.Context, limit, offset int) ([]client.BoardListDtoBase, client.PagingMetadata, error) {
+	p := client.BoardControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.BoardControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list boards", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchProjects(ctx context.Context, limit, offset int) ([]client.ProjectListDtoBase, client.PagingMetadata, error) {
+	p := client.ProjectControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.ProjectControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list projects", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchDepartments(ctx context.Context, limit, offset int) ([]client.DepartmentListDtoBase, client.PagingMetadata, error) {
+	p := client.DepartmentControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.DepartmentControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list departments", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchStringStickers(ctx context.Context, limit, offset int) ([]client.StringStickerWithStatesListDtoBase, client.PagingMetadata, error) {
+	p := client.StringStickerControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.StringStickerControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list string stickers", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+func (n *names) fetchSprintStickers(ctx context.Context, limit, offset int) ([]client.SprintStickerWithStatesListDtoBase, client.PagingMetadata, error) {
+	p := client.SprintStickerControllerSearchParams{IncludeDeleted: boolPtr(true)}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.SprintStickerControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list sprint stickers", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// namesServer serves one task and the collections names resolves it with,
+// counting requests per path.
+func namesServer(t *testing.T) (*httptest.Server, map[string]int) {
+	t.Helper()
+	var mu sync.Mutex
+	hits := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := map[string]string{
+			"/api-v2/task-list":       `{"content":[{"id":"t1","title":"Задача","columnId":"c1","assigned":["u1"],"stickers":{"s1":"st1"},"timestamp":0}],"paging":{}}`,
+			"/api-v2/columns":         `{"content":[{"id":"c1","title":"Todo","boardId":"b1"}],"paging":{}}`,
+			"/api-v2/boards":          `{"content":[{"id":"b1","title":"Main","projectId":"p1"}],"paging":{}}`,
+			"/api-v2/users":           `{"content":[{"id":"u1","email":"ivan@x.io","realName":"Иван"}],"paging":{}}`,
+			"/api-v2/string-stickers": `{"content":[{"id":"s1","name":"Priority","states":[{"id":"st1","name":"High"}]}],"paging":{}}`,
+			"/api-v2/sprint-stickers": `{"content":[],"paging":{}}`,
+		}[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, hits
+}
+
+func runTasksList(t *testing.T, g *Globals) string {
+	t.Helper()
+	c := NewTasksListCmd(g)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetArgs(nil)
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	return buf.String()
+}
+
+func TestTasksListCmd_ResolvesNamesFromCache(t *testing.T) {
+	srv, hits := namesServer(t)
+	g := statusGlobals(t, srv.URL)
+	g.Output = func() string { return "wide" }
+
+	for i := 0; i < 2; i++ {
+		out := runTasksList(t, g)
+		for _, want := range []string{"Main / Todo", "Иван", "Priority: High"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("run %d: output missing %q:\n%s", i, want, out)
+			}
+		}
+	}
+	if hits["/api-v2/users"] != 1 || hits["/api-v2/columns"] != 1 {
+		t.Errorf("collections fetched %d/%d times, want once (second run from cache)", hits["/api-v2/users"], hits["/api-v2/columns"])
+	}
+}
+
+func TestTasksListCmd_RawIDs_SkipsLookups(t *testing.T) {
+	srv, hits := namesServer(t)
+	g := statusGlobals(t, srv.URL)
+	g.RawIDs = func() bool { return true }
+
+	out := runTasksList(t, g)
+	if !strings.Contains(out, "c1") || !strings.Contains(out, "u1") {
+		t.Errorf("want raw IDs:\n%s", out)
+	}
+	if hits["/api-v2/users"] != 0 || hits["/api-v2/columns"] != 0 {
+		t.Errorf("--raw-ids must not fetch collections: %v", hits)
+	}
+}
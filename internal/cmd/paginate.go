@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// pageFetcher requests a single page of a search endpoint at limit/offset.
+type pageFetcher[T any] func(ctx context.Context, limit, offset int) ([]T, client.PagingMetadata, error)
+
+// fetchPages requests pages starting at offset and passes each one to emit as it arrives.
+// If all is false, exactly one page is requested. Otherwise pages are requested
+// until the API reports PagingMetadata.Next == false (or returns an empty page).
+// Returns paging metadata describing everything that was emitted.
+func fetchPages[T any](ctx context.Context, limit, offset int, all bool, fetch pageFetcher[T], emit func([]T) error) (client.PagingMetadata, error) {
+	total := client.PagingMetadata{Limit: float32(limit), Offset: float32(offset)}
+	for {
+		items, paging, err := fetch(ctx, limit, offset)
+		if err != nil {
+			return total, err
+		}
+		if err := emit(items); err != nil {
+			return total, err
+		}
+		total.Count += float32(len(items))
+		total.Next = paging.Next
+		if !all || !paging.Next || len(items) == 0 {
+			break
+		}
+		offset += len(items)
+	}
+	if all {
+		total.Next = false
+	}
+	return total, nil
+}
+
+// collectPages is fetchPages that accumulates every emitted page into one slice.
+func collectPages[T any](ctx context.Context, limit, offset int, all bool, fetch pageFetcher[T]) ([]T, client.PagingMetadata, error) {
+	var items []T
+	paging, err := fetchPages(ctx, limit, offset, all, fetch, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if items == nil {
+		items = []T{}
+	}
+	return items, paging, err
+}
+
+// listPages fetches a list like collectPages and prints result(items, paging).
+// With all and an output that can be streamed (see Globals.streamsPages) each
+// page is printed as it arrives instead of after the last one.
+func listPages[T any](ctx context.Context, g *Globals, w io.Writer, limit, offset int, all bool, fetch pageFetcher[T], result func([]T, client.PagingMetadata) output.Result) error {
+	if all && g.streamsPages() {
+		return streamPages(ctx, g, w, limit, offset, fetch, nil, result)
+	}
+	items, paging, err := collectPages(ctx, limit, offset, all, fetch)
+	if err != nil {
+		return err
+	}
+	return g.print(w, result(items, paging))
+}
+
+// streamPages requests every page from offset and prints the items match
+// accepts (all of them if match is nil) page by page. The first page is
+// always printed, so csv and tsv get their header even for an empty list.
+func streamPages[T any](ctx context.Context, g *Globals, w io.Writer, pageSize, offset int, fetch pageFetcher[T], match func(T) bool, result func([]T, client.PagingMetadata) output.Result) error {
+	first := true
+	_, err := fetchPages(ctx, pageSize, offset, true, fetch, func(page []T) error {
+		items := page
+		if match != nil {
+			items = []T{}
+			for _, it := range page {
+				if match(it) {
+					items = append(items, it)
+				}
+			}
+		}
+		if len(items) == 0 && !first {
+			return nil
+		}
+		if items == nil {
+			items = []T{}
+		}
+		r := result(items, client.PagingMetadata{Count: float32(len(items))})
+		err := g.printPage(w, r, first)
+		first = false
+		return err
+	})
+	return err
+}
+
+// pageParams returns the limit/offset query values for one page request.
+// A zero limit or offset is left unset so the API default applies.
+func pageParams(limit, offset int) (*float32, *float32) {
+	var l, o *float32
+	if limit > 0 {
+		l = float32Ptr(float32(limit))
+	}
+	if offset > 0 {
+		o = float32Ptr(float32(offset))
+	}
+	return l, o
+}
+
+// collectMatches scans pages of pageSize starting at offset and keeps the
+// items match accepts, until limit of them are found (with all, every page is
+// scanned). The paging metadata counts the kept items; Next reports that the
+// scan stopped at limit with more items left to check.
+func collectMatches[T any](ctx context.Context, pageSize, limit, offset int, all bool, fetch pageFetcher[T], match func(T) bool) ([]T, client.PagingMetadata, error) {
+	items := []T{}
+	more := false
+	errLimit := errors.New("limit reached")
+	paging, err := fetchPages(ctx, pageSize, offset, true, fetch, func(page []T) error {
+		for _, it := range page {
+			if !match(it) {
+				continue
+			}
+			if !all && len(items) == limit {
+				more = true
+				return errLimit
+			}
+			items = append(items, it)
+		}
+		return nil
+	})
+	if errors.Is(err, errLimit) {
+		err = nil
+	}
+	paging.Limit, paging.Offset = float32(limit), float32(offset)
+	paging.Count, paging.Next = float32(len(items)), more
+	return items, paging, err
+}
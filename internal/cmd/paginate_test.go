@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// fakePages serves items in pages of limit, like the API search endpoints.
+func fakePages(items []string, calls *int) pageFetcher[string] {
+	return func(_ context.Context, limit, offset int) ([]string, client.PagingMetadata, error) {
+		*calls++
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		page := items[offset:end]
+		return page, client.PagingMetadata{
+			Count:  float32(len(page)),
+			Limit:  float32(limit),
+			Offset: float32(offset),
+			Next:   end < len(items),
+		}, nil
+	}
+}
+
+func TestCollectPages_All_FollowsNextUntilFalse(t *testing.T) {
+	items := make([]string, 7)
+	for i := range items {
+		items[i] = fmt.Sprintf("t%d", i)
+	}
+	calls := 0
+
+	got, paging, err := collectPages(context.Background(), 3, 0, true, fakePages(items, &calls))
+	if err != nil {
+		t.Fatalf("collectPages: %v", err)
+	}
+	if len(got) != 7 || got[6] != "t6" {
+		t.Errorf("got %v, want all 7 items", got)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if paging.Count != 7 || paging.Next {
+		t.Errorf("paging = %+v, want count 7 and next=false", paging)
+	}
+}
+
+func TestCollectPages_NotAll_FetchesSinglePage(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	calls := 0
+
+	got, paging, err := collectPages(context.Background(), 2, 1, false, fakePages(items, &calls))
+	if err != nil {
+		t.Fatalf("collectPages: %v", err)
+	}
+	if len(got) != 2 || got[0] != "b" {
+		t.Errorf("got %v, want [b c]", got)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if !paging.Next {
+		t.Error("paging.Next should be passed through when not fetching all")
+	}
+}
+
+func TestFetchPages_EmitsEachPageInOrder(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	calls := 0
+	var pages [][]string
+
+	_, err := fetchPages(context.Background(), 2, 0, true, fakePages(items, &calls), func(page []string) error {
+		pages = append(pages, page)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fetchPages: %v", err)
+	}
+	if len(pages) != 3 || pages[2][0] != "e" {
+		t.Errorf("pages = %v, want 3 pages ending with [e]", pages)
+	}
+}
+
+func TestFetchPages_FetchError_Stops(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, limit, offset int) ([]string, client.PagingMetadata, error) {
+		calls++
+		return nil, client.PagingMetadata{}, fmt.Errorf("boom")
+	}
+
+	_, err := fetchPages(context.Background(), 2, 0, true, fetch, func([]string) error { return nil })
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestCollectMatches_ScansPagesUntilLimit(t *testing.T) {
+	items := make([]string, 10)
+	for i := range items {
+		items[i] = fmt.Sprintf("t%d", i)
+	}
+	odd := func(s string) bool { return (s[1]-'0')%2 == 1 }
+	calls := 0
+
+	got, paging, err := collectMatches(context.Background(), 3, 3, 0, false, fakePages(items, &calls), odd)
+	if err != nil {
+		t.Fatalf("collectMatches: %v", err)
+	}
+	if fmt.Sprint(got) != "[t1 t3 t5]" || !paging.Next || paging.Count != 3 {
+		t.Errorf("got %v, paging %+v; want [t1 t3 t5] with next", got, paging)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (stop once the limit is exceeded)", calls)
+	}
+
+	got, paging, _ = collectMatches(context.Background(), 3, 3, 0, true, fakePages(items, &calls), odd)
+	if len(got) != 5 || paging.Next {
+		t.Errorf("all: got %v, paging %+v; want 5 matches without next", got, paging)
+	}
+}
+
+// listItems lists items with listPages in pages of 2 and returns the output
+// and what had been printed when each page was requested.
+func listItems(t *testing.T, format string, items []string) (string, []string) {
+	t.Helper()
+	var out bytes.Buffer
+	var seen []string
+	calls := 0
+	pages := fakePages(items, &calls)
+	fetch := func(ctx context.Context, limit, offset int) ([]string, client.PagingMetadata, error) {
+		seen = append(seen, out.String())
+		return pages(ctx, limit, offset)
+	}
+	g := &Globals{Output: func() string { return format }}
+	err := listPages(context.Background(), g, &out, 2, 0, true, fetch, func(page []string, paging client.PagingMetadata) output.Result {
+		rows := make([][]string, len(page))
+		for i, s := range page {
+			rows[i] = []string{s}
+		}
+		return output.Result{Value: page, Items: page, Table: output.NewTable([]string{"ID"}, rows)}
+	})
+	if err != nil {
+		t.Fatalf("listPages: %v", err)
+	}
+	return out.String(), seen
+}
+
+func TestListPages_All_StreamsCSV(t *testing.T) {
+	out, seen := listItems(t, "csv", []string{"a", "b", "c", "d", "e"})
+	if out != "ID\na\nb\nc\nd\ne\n" {
+		t.Errorf("output = %q, want one header and every row", out)
+	}
+	if len(seen) != 3 || seen[1] != "ID\na\nb\n" || seen[2] != "ID\na\nb\nc\nd\n" {
+		t.Errorf("printed before each request = %q, want each page printed as it arrived", seen)
+	}
+
+	_, seen = listItems(t, "ndjson", []string{"a", "b", "c"})
+	if len(seen) != 2 || seen[1] != "\"a\"\n\"b\"\n" {
+		t.Errorf("ndjson printed before each request = %q", seen)
+	}
+}
+
+func TestListPages_All_JSONPrintsOnce(t *testing.T) {
+	out, seen := listItems(t, "json", []string{"a", "b", "c"})
+	if strings.Join(seen, "") != "" {
+		t.Errorf("printed before the last page: %q", seen)
+	}
+	if out != `["a","b","c"]`+"\n" {
+		t.Errorf("output = %q", out)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -11,61 +10,59 @@ import (
 )
 
 // NewProjectsListCmd returns the "projects list" command.
-func NewProjectsListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectsListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var title string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List projects",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
 
-			params := &client.ProjectControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.ProjectControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
 
-			resp, err := api.ProjectControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list projects: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list projects: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list projects: empty response")
-			}
-
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Title"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, p := range resp.JSON200.Content {
-				rows = append(rows, []string{p.Id, p.Title})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.ProjectListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.ProjectControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list projects: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list projects", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list projects: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(projects []client.ProjectListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Title"}
+				rows := make([][]string, 0, len(projects))
+				for _, p := range projects {
+					rows = append(rows, []string{p.Id, p.Title})
+				}
+				return output.Result{Value: client.ProjectListDto{Content: projects, Paging: paging}, Items: projects, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
 	return c
 }
 
 // NewProjectsCreateCmd returns the "projects create" command.
-func NewProjectsCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectsCreateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "create",
@@ -74,7 +71,7 @@ func NewProjectsCreateCmd(resolvePath func() (string, error), outputJSON func()
 			if title == "" {
 				return fmt.Errorf("title is required (--title)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -84,17 +81,13 @@ func NewProjectsCreateCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("create project: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create project: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create project", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Project created: id=%s\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Project created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "project title")
@@ -103,18 +96,21 @@ func NewProjectsCreateCmd(resolvePath func() (string, error), outputJSON func()
 }
 
 // NewProjectsUpdateCmd returns the "projects update" command.
-func NewProjectsUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectsUpdateCmd(g *Globals) *cobra.Command {
 	var title string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a project",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveProject(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			body := client.ProjectControllerUpdateJSONRequestBody{}
 			if cmd.Flags().Changed("title") {
 				body.Title = &title
@@ -124,14 +120,10 @@ func NewProjectsUpdateCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("update project: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update project: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update project", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Project updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Project updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "project title")
@@ -139,44 +131,43 @@ func NewProjectsUpdateCmd(resolvePath func() (string, error), outputJSON func()
 }
 
 // NewProjectGetCmd returns the "projects get" command.
-func NewProjectGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
-		Short: "Get project by ID",
+		Short: "Get project by ID or name",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveProject(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 
 			resp, err := api.ProjectControllerGetWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("get project: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get project: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get project", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get project: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewProjectRolesListCmd returns the "projects roles list" command.
-func NewProjectRolesListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectRolesListCmd(g *Globals) *cobra.Command {
 	var projectID string
 	var limit, offset int
+	var all bool
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List project roles",
@@ -184,48 +175,46 @@ func NewProjectRolesListCmd(resolvePath func() (string, error), outputJSON func(
 			if projectID == "" {
 				return fmt.Errorf("project-id is required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
-			params := &client.ProjectRolesControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
-			resp, err := api.ProjectRolesControllerSearchWithResponse(context.Background(), projectID, params)
-			if err != nil {
-				return fmt.Errorf("list roles: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list roles: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list roles: empty response")
-			}
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Name"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, r := range resp.JSON200.Content {
-				rows = append(rows, []string{r.Id, r.Name})
-			}
-			return output.PrintTable(out, headers, rows)
+			params := client.ProjectRolesControllerSearchParams{}
+			fetch := func(ctx context.Context, limit, offset int) ([]client.ProjectRoleListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.ProjectRolesControllerSearchWithResponse(ctx, projectID, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list roles: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list roles", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list roles: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(roles []client.ProjectRoleListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Name"}
+				rows := make([][]string, 0, len(roles))
+				for _, r := range roles {
+					rows = append(rows, []string{r.Id, r.Name})
+				}
+				return output.Result{Value: client.ProjectRoleListDto{Content: roles, Paging: paging}, Items: roles, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
 	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
 	c.Flags().IntVar(&limit, "limit", 50, "max items")
 	c.Flags().IntVar(&offset, "offset", 0, "offset")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	_ = c.MarkFlagRequired("project-id")
 	return c
 }
 
 // NewProjectRolesGetCmd returns the "projects roles get" command.
-func NewProjectRolesGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectRolesGetCmd(g *Globals) *cobra.Command {
 	var projectID string
 	c := &cobra.Command{
 		Use:   "get [role-id]",
@@ -235,7 +224,7 @@ func NewProjectRolesGetCmd(resolvePath func() (string, error), outputJSON func()
 			if projectID == "" {
 				return fmt.Errorf("project-id is required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -245,18 +234,13 @@ func NewProjectRolesGetCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("get role: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get role: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get role", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get role: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
@@ -265,7 +249,7 @@ func NewProjectRolesGetCmd(resolvePath func() (string, error), outputJSON func()
 }
 
 // NewProjectRolesCreateCmd returns the "projects roles create" command.
-func NewProjectRolesCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectRolesCreateCmd(g *Globals) *cobra.Command {
 	var projectID, name, description string
 	c := &cobra.Command{
 		Use:   "create",
@@ -274,7 +258,7 @@ func NewProjectRolesCreateCmd(resolvePath func() (string, error), outputJSON fun
 			if projectID == "" || name == "" {
 				return fmt.Errorf("project-id and name are required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -290,17 +274,13 @@ func NewProjectRolesCreateCmd(resolvePath func() (string, error), outputJSON fun
 				return fmt.Errorf("create role: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create role: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create role", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Role created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Role created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
@@ -312,7 +292,7 @@ func NewProjectRolesCreateCmd(resolvePath func() (string, error), outputJSON fun
 }
 
 // NewProjectRolesUpdateCmd returns the "projects roles update" command.
-func NewProjectRolesUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectRolesUpdateCmd(g *Globals) *cobra.Command {
 	var projectID, name, description string
 	c := &cobra.Command{
 		Use:   "update [role-id]",
@@ -322,7 +302,7 @@ func NewProjectRolesUpdateCmd(resolvePath func() (string, error), outputJSON fun
 			if projectID == "" {
 				return fmt.Errorf("project-id is required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -339,14 +319,10 @@ func NewProjectRolesUpdateCmd(resolvePath func() (string, error), outputJSON fun
 				return fmt.Errorf("update role: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update role: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update role", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Role updated: id=%s\n", roleID)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Role updated: id=%s", roleID)})
 		},
 	}
 	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
@@ -357,7 +333,7 @@ func NewProjectRolesUpdateCmd(resolvePath func() (string, error), outputJSON fun
 }
 
 // NewProjectRolesDeleteCmd returns the "projects roles delete" command.
-func NewProjectRolesDeleteCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectRolesDeleteCmd(g *Globals) *cobra.Command {
 	var projectID string
 	c := &cobra.Command{
 		Use:   "delete [role-id]",
@@ -367,7 +343,7 @@ func NewProjectRolesDeleteCmd(resolvePath func() (string, error), outputJSON fun
 			if projectID == "" {
 				return fmt.Errorf("project-id is required")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -377,10 +353,9 @@ func NewProjectRolesDeleteCmd(resolvePath func() (string, error), outputJSON fun
 				return fmt.Errorf("delete role: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("delete role: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("delete role", resp.HTTPResponse, resp.Body)
 			}
-			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Role deleted: id=%s\n", roleID)
-			return err
+			return g.print(cmd.OutOrStdout(), output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Role deleted: id=%s", roleID)})
 		},
 	}
 	c.Flags().StringVar(&projectID, "project-id", "", "project ID")
@@ -389,21 +364,21 @@ func NewProjectRolesDeleteCmd(resolvePath func() (string, error), outputJSON fun
 }
 
 // NewProjectsCmd returns the "projects" parent command.
-func NewProjectsCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewProjectsCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "projects",
 		Short: "Manage projects",
 	}
-	c.AddCommand(NewProjectsListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewProjectGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewProjectsCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewProjectsUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewProjectsListCmd(g))
+	c.AddCommand(NewProjectGetCmd(g))
+	c.AddCommand(NewProjectsCreateCmd(g))
+	c.AddCommand(NewProjectsUpdateCmd(g))
 	rolesCmd := &cobra.Command{Use: "roles", Short: "Project roles"}
-	rolesCmd.AddCommand(NewProjectRolesListCmd(resolvePath, outputJSON))
-	rolesCmd.AddCommand(NewProjectRolesGetCmd(resolvePath, outputJSON))
-	rolesCmd.AddCommand(NewProjectRolesCreateCmd(resolvePath, outputJSON))
-	rolesCmd.AddCommand(NewProjectRolesUpdateCmd(resolvePath, outputJSON))
-	rolesCmd.AddCommand(NewProjectRolesDeleteCmd(resolvePath, outputJSON))
+	rolesCmd.AddCommand(NewProjectRolesListCmd(g))
+	rolesCmd.AddCommand(NewProjectRolesGetCmd(g))
+	rolesCmd.AddCommand(NewProjectRolesCreateCmd(g))
+	rolesCmd.AddCommand(NewProjectRolesUpdateCmd(g))
+	rolesCmd.AddCommand(NewProjectRolesDeleteCmd(g))
 	c.AddCommand(rolesCmd)
 	return c
 }
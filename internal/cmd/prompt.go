@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// errNoTTY is returned by prompts when stdin is not a terminal.
+var errNoTTY = errors.New("stdin is not a terminal")
+
+// stdinIsTerminal reports whether stdin is an interactive terminal.
+// It is a variable so tests can fake a TTY.
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readPassword reads a secret from the terminal; tests replace it.
+var readPassword = promptSecret
+
+// promptSecret prints label to stderr and reads a line from the terminal
+// without echoing it.
+func promptSecret(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errNoTTY
+	}
+	_, _ = fmt.Fprint(os.Stderr, label)
+	b, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// promptChoice writes title and the numbered options to w, then reads a
+// number from r until it names an option. Returns the option's index.
+func promptChoice(r io.Reader, w io.Writer, title string, options []string) (int, error) {
+	_, _ = fmt.Fprintln(w, title)
+	for i, o := range options {
+		_, _ = fmt.Fprintf(w, "  %d) %s\n", i+1, o)
+	}
+	sc := bufio.NewScanner(r)
+	for {
+		_, _ = fmt.Fprintf(w, "Enter number [1-%d]: ", len(options))
+		if !sc.Scan() {
+			if err := sc.Err(); err != nil {
+				return 0, err
+			}
+			return 0, errors.New("no selection made")
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(sc.Text()))
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		_, _ = fmt.Fprintf(w, "Invalid choice %q\n", sc.Text())
+	}
+}
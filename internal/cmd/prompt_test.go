@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func TestPromptChoice_RetriesUntilValid(t *testing.T) {
+	var out bytes.Buffer
+	i, err := promptChoice(strings.NewReader("x\n9\n2\n"), &out, "Pick:", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("promptChoice: %v", err)
+	}
+	if i != 1 {
+		t.Errorf("index = %d, want 1", i)
+	}
+	if !strings.Contains(out.String(), "  2) b") || strings.Count(out.String(), "Invalid choice") != 2 {
+		t.Errorf("unexpected prompt output:\n%s", out.String())
+	}
+}
+
+func TestPromptChoice_EOF_Errors(t *testing.T) {
+	if _, err := promptChoice(strings.NewReader(""), new(bytes.Buffer), "Pick:", []string{"a"}); err == nil {
+		t.Fatal("expected error on EOF")
+	}
+}
+
+func TestChooseCompany_MultipleWithoutTTY_ListsCompanies(t *testing.T) {
+	old := stdinIsTerminal
+	stdinIsTerminal = func() bool { return false }
+	defer func() { stdinIsTerminal = old }()
+
+	companies := []client.CompanyListDtoBase{{Id: "c1", Name: "Acme", IsAdmin: true}, {Id: "c2", Name: "Partner"}}
+	_, err := chooseCompany(&cobra.Command{}, companies, "", "")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"c1", "Acme (admin)", "c2", "--company-id"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should contain %q", err, want)
+		}
+	}
+}
+
+func TestChooseCompany_TTY_UsesPicker(t *testing.T) {
+	old := stdinIsTerminal
+	stdinIsTerminal = func() bool { return true }
+	defer func() { stdinIsTerminal = old }()
+
+	c := &cobra.Command{}
+	c.SetIn(strings.NewReader("2\n"))
+	c.SetErr(new(bytes.Buffer))
+	companies := []client.CompanyListDtoBase{{Id: "c1", Name: "Acme"}, {Id: "c2", Name: "Partner"}}
+	got, err := chooseCompany(c, companies, "", "")
+	if err != nil {
+		t.Fatalf("chooseCompany: %v", err)
+	}
+	if got.Id != "c2" {
+		t.Errorf("company = %q, want c2", got.Id)
+	}
+}
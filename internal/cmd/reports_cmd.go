@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// timeReportRow is one group of "reports time"; hours are summed in float64.
+type timeReportRow struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Tasks int     `json:"tasks"`
+	Plan  float64 `json:"plan"`
+	Work  float64 `json:"work"`
+}
+
+func (r *timeReportRow) add(tt *client.TimeTracking) {
+	r.Tasks++
+	r.Plan += float64(tt.Plan)
+	r.Work += float64(tt.Work)
+}
+
+// cells formats the row for the table.
+func (r timeReportRow) cells() []string {
+	progress := ""
+	if r.Plan > 0 {
+		progress = fmt.Sprintf("%.0f%%", 100*r.Work/r.Plan)
+	}
+	return []string{r.Name, strconv.Itoa(r.Tasks), formatHours(r.Plan), formatHours(r.Work), formatHours(r.Plan - r.Work), progress}
+}
+
+// formatHours formats hours with at most two decimals, e.g. 1.5 or 0.25.
+func formatHours(h float64) string {
+	return strconv.FormatFloat(math.Round(h*100)/100, 'f', -1, 64)
+}
+
+// timeReport sums the time tracking of tasks per group.
+type timeReport struct {
+	by     string
+	res    *names // resolves columns to boards and projects
+	groups map[string]*timeReportRow
+	total  timeReportRow
+}
+
+// groupIDs returns the groups task t counts in: its assignees, board or
+// project. "" stands for unassigned or an unknown column.
+func (r *timeReport) groupIDs(t client.TaskListDtoBase) []string {
+	if r.by == "user" {
+		if t.Assigned == nil || len(*t.Assigned) == 0 {
+			return []string{""}
+		}
+		return *t.Assigned
+	}
+	if t.ColumnId == nil {
+		return []string{""}
+	}
+	col, ok := r.res.column(*t.ColumnId)
+	if !ok {
+		return []string{""}
+	}
+	if r.by == "board" {
+		return []string{col.BoardId}
+	}
+	b, ok := r.res.boardDto(col.BoardId)
+	if !ok {
+		return []string{""}
+	}
+	return []string{b.ProjectId}
+}
+
+func (r *timeReport) add(t client.TaskListDtoBase) {
+	tt := t.TimeTracking
+	if tt == nil || (tt.Plan == 0 && tt.Work == 0) {
+		return
+	}
+	r.total.add(tt)
+	for _, id := range r.groupIDs(t) {
+		g := r.groups[id]
+		if g == nil {
+			g = &timeReportRow{ID: id}
+			r.groups[id] = g
+		}
+		g.add(tt)
+	}
+}
+
+// rows returns the groups named with n (IDs with a nil n), most work first.
+func (r *timeReport) rows(n *names) []timeReportRow {
+	rows := make([]timeReportRow, 0, len(r.groups))
+	for _, g := range r.groups {
+		row := *g
+		switch {
+		case row.ID == "" && r.by == "user":
+			row.Name = "(unassigned)"
+		case row.ID == "":
+			row.Name = "(unknown)"
+		case r.by == "user":
+			row.Name = n.user(row.ID)
+		case r.by == "board":
+			row.Name = n.project(r.boardProject(row.ID)) + " / " + n.board(row.ID)
+		default:
+			row.Name = n.project(row.ID)
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Work != rows[j].Work {
+			return rows[i].Work > rows[j].Work
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+func (r *timeReport) boardProject(board string) string {
+	if b, ok := r.res.boardDto(board); ok {
+		return b.ProjectId
+	}
+	return ""
+}
+
+// NewReportsTimeCmd returns the "reports time" command.
+func NewReportsTimeCmd(g *Globals) *cobra.Command {
+	var by, project, board, assigned, completedStr, archivedStr string
+	c := &cobra.Command{
+		Use:   "time",
+		Short: "Sum planned and worked hours by user, project or board",
+		Long: `Sum the planned and worked hours of tasks (their time tracking) by user,
+project or board. Every page of the task list is scanned; tasks without
+tracked time are skipped.
+
+With --by user a task counts in full for each of its assignees, so the
+groups may add up to more than the total, which counts every task once.`,
+		Example: `  yougile reports time --by project
+  yougile reports time --by user --project Marketing --completed true -o csv`,
+		Args: cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			if by != "user" && by != "project" && by != "board" {
+				return fmt.Errorf("--by must be user, project or board")
+			}
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			res := g.newNames(ctx, api, s)
+
+			params := client.TaskControllerSearchParams{}
+			if assigned != "" {
+				ids, err := res.resolveUsers(assigned)
+				if err != nil {
+					return err
+				}
+				params.AssignedTo = strPtr(strings.Join(ids, ","))
+			}
+			var filter taskFilter
+			if filter.columns, err = res.scopeColumns(board, project); err != nil {
+				return err
+			}
+			if c.Flags().Changed("completed") {
+				if filter.completed = parseOptionalBool(completedStr); filter.completed == nil {
+					return fmt.Errorf("--completed must be true or false")
+				}
+			}
+			if c.Flags().Changed("archived") {
+				if filter.archived = parseOptionalBool(archivedStr); filter.archived == nil {
+					return fmt.Errorf("--archived must be true or false")
+				}
+			}
+
+			report := &timeReport{by: by, res: res, groups: map[string]*timeReportRow{}}
+			_, err = fetchPages(ctx, lookupPageSize, 0, true, taskSearch(api, params), func(tasks []client.TaskListDtoBase) error {
+				for _, t := range tasks {
+					if filter.match(t) {
+						report.add(t)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			rows := report.rows(g.lookupNames(ctx, api, s))
+			report.total.Name = "Total"
+			table := output.NewTable([]string{strings.ToUpper(by[:1]) + by[1:], "Tasks", "Plan (h)", "Work (h)", "Left (h)", "Of plan"}, nil)
+			for _, r := range rows {
+				table.Rows = append(table.Rows, r.cells())
+			}
+			table.Rows = append(table.Rows, report.total.cells())
+			value := struct {
+				By     string          `json:"by"`
+				Groups []timeReportRow `json:"groups"`
+				Total  timeReportRow   `json:"total"`
+			}{by, rows, report.total}
+			return g.print(c.OutOrStdout(), output.Result{Value: value, Items: rows, Table: table})
+		},
+	}
+	c.Flags().StringVar(&by, "by", "user", "group by user, project or board")
+	c.Flags().StringVar(&project, "project", "", "only tasks in this project (ID or name)")
+	c.Flags().StringVar(&board, "board", "", `only tasks on this board: ID, "Board" or "Project/Board"`)
+	c.Flags().StringVar(&assigned, "assigned", "", `only tasks assigned to these users: comma-separated IDs, emails, names or "me"`)
+	c.Flags().StringVar(&completedStr, "completed", "", "only completed (true) or open (false) tasks")
+	c.Flags().StringVar(&archivedStr, "archived", "", "only archived (true) or active (false) tasks")
+	return c
+}
+
+// NewReportsCmd returns the "reports" parent command.
+func NewReportsCmd(g *Globals) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "reports",
+		Short: "Reports built from the task list",
+	}
+	c.AddCommand(NewReportsTimeCmd(g))
+	return c
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// timeTasks are spread over Marketing (c1) and Dev (c2, c3); t4 is shared
+// by both users, t5 has no tracked time and t6 is completed.
+const timeTasks = `{"id":"t1","title":"A","columnId":"c1","assigned":["u1"],"timeTracking":{"plan":4,"work":1.5}},` +
+	`{"id":"t2","title":"B","columnId":"c2","assigned":["u2"],"timeTracking":{"plan":2,"work":3}},` +
+	`{"id":"t3","title":"C","columnId":"c3","timeTracking":{"plan":0,"work":0.25}},` +
+	`{"id":"t4","title":"D","columnId":"c3","assigned":["u1","u2"],"timeTracking":{"plan":1,"work":1}},` +
+	`{"id":"t5","title":"E","columnId":"c1","assigned":["u1"]},` +
+	`{"id":"t6","title":"F","columnId":"c1","assigned":["u2"],"completed":true,"timeTracking":{"plan":8,"work":8}}`
+
+func reportTime(t *testing.T, args ...string) map[string]interface{} {
+	t.Helper()
+	srv := newResolveServer(t)
+	srv.taskList = timeTasks
+	g := statusGlobals(t, srv.URL)
+	g.Output = func() string { return "json" }
+	out := runCmd(t, NewReportsCmd(g), append([]string{"time"}, args...)...)
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	return v
+}
+
+func groupSums(v map[string]interface{}) map[string][2]float64 {
+	sums := map[string][2]float64{}
+	for _, g := range v["groups"].([]interface{}) {
+		g := g.(map[string]interface{})
+		sums[g["id"].(string)] = [2]float64{g["plan"].(float64), g["work"].(float64)}
+	}
+	return sums
+}
+
+func TestReportsTime_ByProject(t *testing.T) {
+	v := reportTime(t, "--by", "project", "--completed", "false")
+	got := groupSums(v)
+	if got["p1"] != [2]float64{4, 1.5} || got["p2"] != [2]float64{3, 4.25} || len(got) != 2 {
+		t.Errorf("groups = %v", got)
+	}
+	total := v["total"].(map[string]interface{})
+	if total["tasks"].(float64) != 4 || total["work"].(float64) != 5.75 {
+		t.Errorf("total = %v", total)
+	}
+}
+
+func TestReportsTime_ByUser_CountsSharedTasksForEach(t *testing.T) {
+	got := groupSums(reportTime(t, "--project", "Dev"))
+	want := map[string][2]float64{"u1": {1, 1}, "u2": {3, 4}, "": {0, 0.25}}
+	for id, sum := range want {
+		if got[id] != sum {
+			t.Errorf("group %q = %v, want %v", id, got[id], sum)
+		}
+	}
+}
+
+func TestReportsTime_Table(t *testing.T) {
+	srv := newResolveServer(t)
+	srv.taskList = timeTasks
+	out := runCmd(t, NewReportsCmd(statusGlobals(t, srv.URL)), "time", "--by", "board")
+	for _, want := range []string{"Board", "Dev / Main", "Marketing / Main", "Total", "13.75", "92%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// Arguments that name an object accept its ID or a human reference:
+//
+//	column   "In progress", "Dev/In progress" or "Marketing/Dev/In progress"
+//	board    "Dev" or "Marketing/Dev"
+//	project  "Marketing"
+//	user     "me", an email, or a real name
+//	sticker  its name; a sticker state, the state's name
+//	task     a key such as ID-123 (IdTaskCommon) or DEV-45 (IdTaskProject)
+//
+// Names match case-insensitively and ignore spaces around "/". Deleted objects
+// never match. A reference that matches several objects is an error listing them.
+
+var (
+	uuidRe    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	taskKeyRe = regexp.MustCompile(`^[\p{L}0-9]+-[0-9]+$`)
+)
+
+// errNoMatch is wrapped by resolve errors when nothing matches the reference.
+var errNoMatch = errors.New("not found")
+
+// taskKeysTTL is how long resolved task keys are cached; keys rarely change.
+const taskKeysTTL = 30 * 24 * time.Hour
+
+// isID reports whether ref looks like a YouGile ID and can be used as is.
+func isID(ref string) bool {
+	return uuidRe.MatchString(ref)
+}
+
+// candidate is an object a reference may resolve to.
+type candidate struct {
+	id    string
+	label string   // shown when the reference is ambiguous
+	keys  []string // references that match it, normalized
+}
+
+// normalizeRef lowercases ref and trims spaces around "/" separators.
+func normalizeRef(ref string) string {
+	parts := strings.Split(ref, "/")
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, "/")
+}
+
+// pick returns the ID of the only candidate matching ref.
+func pick(kind, ref string, cands []candidate) (string, error) {
+	key := normalizeRef(ref)
+	var found []candidate
+	for _, c := range cands {
+		for _, k := range c.keys {
+			if k == key {
+				found = append(found, c)
+				break
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("%s %q %w", kind, ref, errNoMatch)
+	case 1:
+		return found[0].id, nil
+	}
+	labels := make([]string, len(found))
+	for i, c := range found {
+		labels[i] = fmt.Sprintf("%s (%s)", c.label, c.id)
+	}
+	return "", fmt.Errorf("%s %q is ambiguous, use the ID or a longer path: %s", kind, ref, strings.Join(labels, "; "))
+}
+
+// resolve returns ref if it is an ID, else the ID of the candidate it names.
+// A miss on cached data refetches the collections once, so objects created
+// since they were cached are found. An error loading the candidates (e.g. a
+// 401 or a network error) is returned rather than reported as no match.
+func (n *names) resolve(kind, ref string, candidates func() ([]candidate, error)) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || isID(ref) {
+		return ref, nil
+	}
+	id, err := n.pick(kind, ref, candidates)
+	if errors.Is(err, errNoMatch) && !n.fresh {
+		n.refresh()
+		id, err = n.pick(kind, ref, candidates)
+	}
+	return id, err
+}
+
+// pick loads the candidates and picks the one ref names.
+func (n *names) pick(kind, ref string, candidates func() ([]candidate, error)) (string, error) {
+	cands, err := candidates()
+	if err != nil {
+		return "", fmt.Errorf("resolve %s %q: %w", kind, ref, err)
+	}
+	return pick(kind, ref, cands)
+}
+
+// refresh drops the loaded collections and bypasses the cache from now on.
+func (n *names) refresh() {
+	n.fresh = true
+	n.users, n.columns, n.boards, n.projects, n.departments, n.stickers = nil, nil, nil, nil, nil, nil
+	n.errs = nil
+}
+
+// resolveProject returns the ID of the project ref.
+func (n *names) resolveProject(ref string) (string, error) {
+	return n.resolve("project", ref, func() ([]candidate, error) {
+		if err := n.loadProjects(); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		for _, p := range n.projects {
+			if !isSet(p.Deleted) {
+				cands = append(cands, candidate{id: p.Id, label: p.Title, keys: []string{normalizeRef(p.Title)}})
+			}
+		}
+		return cands, nil
+	})
+}
+
+// resolveBoard returns the ID of the board ref ("Board" or "Project/Board").
+func (n *names) resolveBoard(ref string) (string, error) {
+	return n.resolve("board", ref, func() ([]candidate, error) {
+		if err := errors.Join(n.loadBoards(), n.loadProjects()); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		for _, b := range n.boards {
+			if isSet(b.Deleted) {
+				continue
+			}
+			project := n.project(b.ProjectId)
+			cands = append(cands, candidate{
+				id:    b.Id,
+				label: project + " / " + b.Title,
+				keys:  []string{normalizeRef(b.Title), normalizeRef(project + "/" + b.Title)},
+			})
+		}
+		return cands, nil
+	})
+}
+
+// resolveColumn returns the ID of the column ref ("Column", "Board/Column"
+// or "Project/Board/Column").
+func (n *names) resolveColumn(ref string) (string, error) {
+	return n.resolve("column", ref, func() ([]candidate, error) {
+		if err := errors.Join(n.loadColumns(), n.loadBoards(), n.loadProjects()); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		for _, c := range n.columns {
+			b, ok := n.boardDto(c.BoardId)
+			if isSet(c.Deleted) || !ok || isSet(b.Deleted) {
+				continue
+			}
+			project := n.project(b.ProjectId)
+			cands = append(cands, candidate{
+				id:    c.Id,
+				label: project + " / " + b.Title + " / " + c.Title,
+				keys: []string{
+					normalizeRef(c.Title),
+					normalizeRef(b.Title + "/" + c.Title),
+					normalizeRef(project + "/" + b.Title + "/" + c.Title),
+				},
+			})
+		}
+		return cands, nil
+	})
+}
+
+// resolveUser returns the ID of the user ref: "me" (the profile's email),
+// an email or a real name.
+func (n *names) resolveUser(ref string) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(ref), "me") {
+		if n.email == "" {
+			return "", fmt.Errorf(`user "me": no email saved for this profile (run auth login)`)
+		}
+		ref = n.email
+	}
+	return n.resolve("user", ref, func() ([]candidate, error) {
+		if err := n.loadUsers(); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		for _, u := range n.users {
+			cands = append(cands, candidate{
+				id:    u.Id,
+				label: strings.TrimSpace(u.RealName + " <" + u.Email + ">"),
+				keys:  []string{normalizeRef(u.Email), normalizeRef(u.RealName)},
+			})
+		}
+		return cands, nil
+	})
+}
+
+// resolveUsers resolves a comma-separated list of user references.
+func (n *names) resolveUsers(refs string) ([]string, error) {
+	var ids []string
+	for _, ref := range strings.Split(refs, ",") {
+		if ref = strings.TrimSpace(ref); ref == "" {
+			continue
+		}
+		id, err := n.resolveUser(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// resolveSticker returns the ID of the string or sprint sticker ref.
+func (n *names) resolveSticker(ref string) (string, error) {
+	return n.resolve("sticker", ref, func() ([]candidate, error) {
+		if err := n.loadStickers(); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		for id, st := range n.stickers {
+			cands = append(cands, candidate{id: id, label: st.Name, keys: []string{normalizeRef(st.Name)}})
+		}
+		return cands, nil
+	})
+}
+
+// resolveStickerState returns the ID of the state ref of sticker.
+func (n *names) resolveStickerState(sticker, ref string) (string, error) {
+	return n.resolve("sticker state", ref, func() ([]candidate, error) {
+		if err := n.loadStickers(); err != nil {
+			return nil, err
+		}
+		var cands []candidate
+		if st, ok := n.stickers[sticker]; ok {
+			for id, name := range st.States {
+				cands = append(cands, candidate{id: id, label: st.Name + ": " + name, keys: []string{normalizeRef(name)}})
+			}
+		}
+		return cands, nil
+	})
+}
+
+// resolveTask returns the ID of the task ref: an ID, or a key such as ID-123
+// or DEV-45. Keys are found by scanning the task list and cached.
+func (n *names) resolveTask(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if isID(ref) || !taskKeyRe.MatchString(ref) {
+		return ref, nil
+	}
+	key := strings.ToUpper(ref)
+	keys := map[string]string{}
+	if n.cache != nil {
+		if ok, _ := n.cache.Load("task-keys", kindTTL("task-keys", 0), &keys); ok && keys[key] != "" {
+			return keys[key], nil
+		}
+	}
+	id := ""
+	errFound := errors.New("found")
+	_, err := fetchPages(n.ctx, lookupPageSize, 0, true, n.fetchTasks, func(tasks []client.TaskListDtoBase) error {
+		for _, t := range tasks {
+			if (t.IdTaskCommon != nil && strings.EqualFold(*t.IdTaskCommon, key)) ||
+				(t.IdTaskProject != nil && strings.EqualFold(*t.IdTaskProject, key)) {
+				id = t.Id
+				return errFound
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return "", fmt.Errorf("find task %s: %w", ref, err)
+	}
+	if id == "" {
+		return "", fmt.Errorf("task %q %w", ref, errNoMatch)
+	}
+	if n.cache != nil {
+		keys[key] = id
+		_ = n.cache.Save("task-keys", keys)
+	}
+	return id, nil
+}
+
+// resolveTasks resolves a comma-separated list of task references.
+func (n *names) resolveTasks(refs string) ([]string, error) {
+	var ids []string
+	for _, ref := range strings.Split(refs, ",") {
+		if ref = strings.TrimSpace(ref); ref == "" {
+			continue
+		}
+		id, err := n.resolveTask(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (n *names) fetchTasks(ctx context.Context, limit, offset int) ([]client.TaskListDtoBase, client.PagingMetadata, error) {
+	p := client.TaskControllerSearchParams{}
+	p.Limit, p.Offset = pageParams(limit, offset)
+	resp, err := n.api.TaskControllerSearchWithResponse(ctx, &p)
+	if err != nil {
+		return nil, client.PagingMetadata{}, err
+	}
+	if resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return nil, client.PagingMetadata{}, apiError("list tasks", resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200.Content, resp.JSON200.Paging, nil
+}
+
+// refFlag registers --name for a reference (name or ID) and keeps the older
+// --name-id spelling as a hidden alias bound to the same variable.
+func refFlag(c *cobra.Command, p *string, name, usage string) {
+	c.Flags().StringVar(p, name, "", usage)
+	c.Flags().StringVar(p, name+"-id", "", usage)
+	_ = c.Flags().MarkHidden(name + "-id")
+}
+
+// refChanged reports whether --name or its --name-id alias was set.
+func refChanged(c *cobra.Command, name string) bool {
+	return c.Flags().Changed(name) || c.Flags().Changed(name+"-id")
+}
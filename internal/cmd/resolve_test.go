@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	clierrors "github.com/angolovin/yougile-cli/internal/errors"
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// resolveServer serves two projects with a "Todo" column each, a few users
+// and tasks, and records the body of the last task create or update. extraColumns is
+// appended to the columns collection, to simulate columns created later;
+// taskList, when set, replaces the content of the task list.
+type resolveServer struct {
+	*httptest.Server
+	mu           sync.Mutex
+	hits         map[string]int
+	extraColumns string
+	taskList     string
+	lastBody     map[string]interface{}
+}
+
+func newResolveServer(t *testing.T) *resolveServer {
+	t.Helper()
+	s := &resolveServer{hits: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.hits[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&s.lastBody)
+			_, _ = io.WriteString(w, `{"id":"t1"}`)
+			return
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&s.lastBody)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"t9"}`)
+			return
+		}
+		tasks := `{"id":"t1","title":"A","idTaskCommon":"ID-7","idTaskProject":"DEV-2"}`
+		if s.taskList != "" {
+			tasks = s.taskList
+		}
+		body, ok := map[string]string{
+			"/api-v2/projects":        `{"content":[{"id":"p1","title":"Marketing"},{"id":"p2","title":"Dev"},{"id":"p3","title":"Old","deleted":true}],"paging":{}}`,
+			"/api-v2/boards":          `{"content":[{"id":"b1","title":"Main","projectId":"p1"},{"id":"b2","title":"Main","projectId":"p2"}],"paging":{}}`,
+			"/api-v2/columns":         `{"content":[{"id":"c1","title":"Todo","boardId":"b1"},{"id":"c2","title":"Todo","boardId":"b2"},{"id":"c3","title":"In progress","boardId":"b2"}` + s.extraColumns + `],"paging":{}}`,
+			"/api-v2/users":           `{"content":[{"id":"u1","email":"me@acme.io","realName":"Me"},{"id":"u2","email":"alice@corp.com","realName":"Alice"}],"paging":{}}`,
+			"/api-v2/string-stickers": `{"content":[{"id":"s1","name":"Priority","states":[{"id":"st1","name":"High"}]},{"id":"s2","name":"Customer"}],"paging":{}}`,
+			"/api-v2/sprint-stickers": `{"content":[],"paging":{}}`,
+			"/api-v2/tasks/t1":        `{"id":"t1","title":"A","deadline":{"deadline":1767225600000,"withTime":true,"links":["t5"],"blockedPoints":["end"]}}`,
+			"/api-v2/task-list":       `{"content":[` + tasks + `],"paging":{}}`,
+		}[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func resolveNames(t *testing.T, g *Globals) *names {
+	t.Helper()
+	_, s, api, err := loadSession(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g.newNames(context.Background(), api, s)
+}
+
+func TestResolveColumn_Paths(t *testing.T) {
+	srv := newResolveServer(t)
+	n := resolveNames(t, statusGlobals(t, srv.URL))
+	for ref, want := range map[string]string{
+		"in progress":                          "c3",
+		"Marketing / Main/Todo":                "c1",
+		"dev/main/todo":                        "c2",
+		"c0ffee00-0000-4000-8000-000000000000": "c0ffee00-0000-4000-8000-000000000000",
+	} {
+		got, err := n.resolveColumn(ref)
+		if err != nil || got != want {
+			t.Errorf("resolveColumn(%q) = %q, %v; want %q", ref, got, err, want)
+		}
+	}
+}
+
+func TestResolveColumn_Ambiguous_ListsCandidates(t *testing.T) {
+	srv := newResolveServer(t)
+	n := resolveNames(t, statusGlobals(t, srv.URL))
+	_, err := n.resolveColumn("Main/Todo")
+	if err == nil {
+		t.Fatal("want ambiguity error")
+	}
+	for _, want := range []string{"ambiguous", "Marketing / Main / Todo (c1)", "Dev / Main / Todo (c2)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestResolveColumn_CacheMiss_RefetchesOnce(t *testing.T) {
+	srv := newResolveServer(t)
+	g := statusGlobals(t, srv.URL)
+	if _, err := resolveNames(t, g).resolveColumn("In progress"); err != nil {
+		t.Fatal(err)
+	}
+	srv.extraColumns = `,{"id":"c4","title":"Review","boardId":"b2"}`
+	n := resolveNames(t, g)
+	if got, err := n.resolveColumn("Review"); err != nil || got != "c4" {
+		t.Errorf("resolveColumn(Review) = %q, %v; want c4 after refetch", got, err)
+	}
+	if _, err := n.resolveColumn("Nope"); !errors.Is(err, errNoMatch) {
+		t.Errorf("err = %v, want errNoMatch", err)
+	}
+	if srv.hits["/api-v2/columns"] != 2 {
+		t.Errorf("columns fetched %d times, want 2 (cached, then one refetch)", srv.hits["/api-v2/columns"])
+	}
+}
+
+func TestResolve_FetchError_KeepsCause(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+	n := resolveNames(t, statusGlobals(t, srv.URL))
+	for name, resolve := range map[string]func(string) (string, error){
+		"user": n.resolveUser, "column": n.resolveColumn, "board": n.resolveBoard,
+		"project": n.resolveProject, "sticker": n.resolveSticker,
+	} {
+		_, err := resolve("Alice")
+		if errors.Is(err, errNoMatch) || clierrors.ExitCode(err) != clierrors.ExitCodeAuth {
+			t.Errorf("resolve %s: err = %v (exit %d), want the 401 (exit %d)", name, err, clierrors.ExitCode(err), clierrors.ExitCodeAuth)
+		}
+	}
+	if got := n.user("u1"); got != "u1" {
+		t.Errorf("display lookup = %q, want the raw ID", got)
+	}
+}
+
+func TestGetCmds_ResolvePositionalNames(t *testing.T) {
+	srv := newResolveServer(t)
+	for _, tc := range []struct {
+		cmd  func(*Globals) *cobra.Command
+		ref  string
+		path string
+	}{
+		{NewBoardGetCmd, "Dev/Main", "/api-v2/boards/b2"},
+		{NewColumnGetCmd, "In progress", "/api-v2/columns/c3"},
+		{NewProjectGetCmd, "marketing", "/api-v2/projects/p1"},
+		{NewUserGetCmd, "alice@corp.com", "/api-v2/users/u2"},
+	} {
+		c := tc.cmd(statusGlobals(t, srv.URL))
+		c.SetArgs([]string{tc.ref})
+		c.SetOut(io.Discard)
+		c.SetErr(io.Discard)
+		_ = c.Execute() // the test server has no single-object endpoints
+		if srv.hits[tc.path] != 1 {
+			t.Errorf("get %q did not request %s: %v", tc.ref, tc.path, srv.hits)
+		}
+	}
+}
+
+func TestResolveProject_SkipsDeleted(t *testing.T) {
+	srv := newResolveServer(t)
+	n := resolveNames(t, statusGlobals(t, srv.URL))
+	if got, err := n.resolveProject("marketing"); err != nil || got != "p1" {
+		t.Errorf("resolveProject = %q, %v", got, err)
+	}
+	if _, err := n.resolveProject("Old"); !errors.Is(err, errNoMatch) {
+		t.Errorf("deleted project resolved: %v", err)
+	}
+}
+
+func TestResolveTask_Keys(t *testing.T) {
+	srv := newResolveServer(t)
+	n := resolveNames(t, statusGlobals(t, srv.URL))
+	for _, ref := range []string{"ID-7", "dev-2", "ID-7"} {
+		if got, err := n.resolveTask(ref); err != nil || got != "t1" {
+			t.Errorf("resolveTask(%q) = %q, %v", ref, got, err)
+		}
+	}
+	if srv.hits["/api-v2/task-list"] != 2 {
+		t.Errorf("task list scanned %d times, want 2 (repeated key from cache)", srv.hits["/api-v2/task-list"])
+	}
+	if _, err := n.resolveTask("ID-99"); !errors.Is(err, errNoMatch) {
+		t.Errorf("err = %v, want errNoMatch", err)
+	}
+}
+
+func TestTasksUpdateCmd_ResolvesColumnUsersAndKey(t *testing.T) {
+	srv := newResolveServer(t)
+	c := NewTasksUpdateCmd(statusGlobals(t, srv.URL))
+	c.SetOut(new(bytes.Buffer))
+	c.SetArgs([]string{"ID-7", "--column", "Dev/Main/In progress", "--assigned", "me, alice@corp.com"})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if got := srv.lastBody["columnId"]; got != "c3" {
+		t.Errorf("columnId = %v, want c3", got)
+	}
+	if got, _ := json.Marshal(srv.lastBody["assigned"]); string(got) != `["u1","u2"]` {
+		t.Errorf("assigned = %s, want [u1 u2]", got)
+	}
+	if srv.hits["/api-v2/tasks/t1"] != 1 {
+		t.Errorf("update did not target the resolved task: %v", srv.hits)
+	}
+}
+
+func TestTasksCreateCmd_AllFields(t *testing.T) {
+	srv := newResolveServer(t)
+	c := NewTasksCreateCmd(statusGlobals(t, srv.URL))
+	runCmd(t, c, "--title", "Release", "--column", "Dev/Main/Todo", "--assigned", "alice@corp.com",
+		"--color", "red", "--deadline", "2024-06-01 18:00", "--time-plan", "2h30m",
+		"--sticker", "Priority=high", "--sticker", "Customer=ООО «Ромашка»",
+		"--checklist", "Steps: draft; [x] outline", "--subtasks", "ID-7", "--deal-amount", "100")
+
+	var got struct {
+		Title        string
+		ColumnId     string
+		Assigned     []string
+		Color        string
+		Deadline     struct{ WithTime bool }
+		TimeTracking struct{ Plan float64 }
+		Stickers     map[string]string
+		Checklists   []struct {
+			Title string
+			Items []struct {
+				Title       string
+				IsCompleted bool
+			}
+		}
+		Subtasks []string
+		Deal     struct{ DealAmount float64 }
+	}
+	data, _ := json.Marshal(srv.lastBody)
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ColumnId != "c2" || len(got.Assigned) != 1 || got.Assigned[0] != "u2" || got.Color != "task-red" {
+		t.Errorf("column/assigned/color = %s", data)
+	}
+	if !got.Deadline.WithTime || got.TimeTracking.Plan != 2.5 || got.Deal.DealAmount != 100 {
+		t.Errorf("deadline/time/deal = %s", data)
+	}
+	if got.Stickers["s1"] != "st1" || got.Stickers["s2"] != "ООО «Ромашка»" {
+		t.Errorf("stickers = %v", got.Stickers)
+	}
+	if len(got.Checklists) != 1 || got.Checklists[0].Title != "Steps" || len(got.Checklists[0].Items) != 2 || !got.Checklists[0].Items[1].IsCompleted {
+		t.Errorf("checklists = %+v", got.Checklists)
+	}
+	if len(got.Subtasks) != 1 || got.Subtasks[0] != "t1" {
+		t.Errorf("subtasks = %v", got.Subtasks)
+	}
+}
+
+func TestTasksUpdateCmd_Color(t *testing.T) {
+	srv := newResolveServer(t)
+	runCmd(t, NewTasksUpdateCmd(statusGlobals(t, srv.URL)), "t1", "--color", "Blue")
+	if got := srv.lastBody["color"]; got != "task-blue" {
+		t.Errorf("color = %v, want task-blue", got)
+	}
+
+	c := NewTasksUpdateCmd(statusGlobals(t, srv.URL))
+	c.SetArgs([]string{"t1", "--color", "plaid"})
+	c.SetOut(io.Discard)
+	if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "unknown color") {
+		t.Errorf("err = %v, want unknown color", err)
+	}
+}
+
+func TestTasksUpdateCmd_Deadline_SendsExactMilliseconds(t *testing.T) {
+	srv := newResolveServer(t)
+	runCmd(t, NewTasksUpdateCmd(statusGlobals(t, srv.URL)), "t1", "--deadline", "2026-03-14 18:07")
+	want := time.Date(2026, 3, 14, 18, 7, 0, 0, time.Local).UnixMilli()
+	d, _ := srv.lastBody["deadline"].(map[string]interface{})
+	if got, _ := d["deadline"].(float64); int64(got) != want {
+		t.Errorf("deadline = %v, want %d", d["deadline"], want)
+	}
+}
+
+func TestTasksUpdateCmd_Start_KeepsDeadlineAndLinks(t *testing.T) {
+	srv := newResolveServer(t)
+	runCmd(t, NewTasksUpdateCmd(statusGlobals(t, srv.URL)), "t1", "--start", "2025-12-20")
+	d, _ := srv.lastBody["deadline"].(map[string]interface{})
+	if d["startDate"] == nil || d["deadline"] != 1.7672256e12 || d["withTime"] != true {
+		t.Errorf("deadline = %v, want the start added to the kept deadline", d)
+	}
+	if links, _ := json.Marshal(d["links"]); string(links) != `["t5"]` {
+		t.Errorf("links = %s, want [t5] kept", links)
+	}
+
+	runCmd(t, NewTasksUpdateCmd(statusGlobals(t, srv.URL)), "t1", "--clear-deadline")
+	if d, _ := srv.lastBody["deadline"].(map[string]interface{}); d["deleted"] != true {
+		t.Errorf("deadline = %v, want deleted", d)
+	}
+}
+
+func TestDeadlineFlags_StartWithoutDeadline_Errors(t *testing.T) {
+	f := deadlineFlags{start: "2025-12-20"}
+	for _, cur := range []*client.Deadline{nil, {}} {
+		if ud, err := f.updateDeadline(cur); err == nil || !strings.Contains(err.Error(), "--start needs --deadline") {
+			t.Errorf("updateDeadline(%v) = %v, %v; want --start needs --deadline", cur, ud, err)
+		}
+	}
+}
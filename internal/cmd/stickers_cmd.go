@@ -10,48 +10,55 @@ import (
 )
 
 // NewStickersStringListCmd returns the "stickers string list" command.
-func NewStickersStringListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var includeDeleted bool
+func NewStickersStringListCmd(g *Globals) *cobra.Command {
+	var limit, offset int
+	var all, includeDeleted bool
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List string stickers",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
-			params := &client.StringStickerControllerSearchParams{}
+			params := client.StringStickerControllerSearchParams{}
 			if includeDeleted {
 				params.IncludeDeleted = boolPtr(true)
 			}
-			resp, err := api.StringStickerControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list string stickers: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list string stickers: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list string stickers: empty response")
-			}
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Name"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, s := range resp.JSON200.Content {
-				rows = append(rows, []string{s.Id, s.Name})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.StringStickerWithStatesListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.StringStickerControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list string stickers: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list string stickers", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list string stickers: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(stickers []client.StringStickerWithStatesListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Name"}
+				rows := make([][]string, 0, len(stickers))
+				for _, s := range stickers {
+					rows = append(rows, []string{s.Id, s.Name})
+				}
+				return output.Result{Value: client.StringStickerWithStatesListDto{Content: stickers, Paging: paging}, Items: stickers, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
+	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
+	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().BoolVar(&includeDeleted, "include-deleted", false, "include deleted stickers")
 	return c
 }
 
 // NewStickersStringCreateCmd returns the "stickers string create" command.
-func NewStickersStringCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringCreateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "create",
@@ -60,7 +67,7 @@ func NewStickersStringCreateCmd(resolvePath func() (string, error), outputJSON f
 			if name == "" {
 				return fmt.Errorf("name is required (--name)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -70,17 +77,13 @@ func NewStickersStringCreateCmd(resolvePath func() (string, error), outputJSON f
 				return fmt.Errorf("create string sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create string sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create string sticker", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "String sticker created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("String sticker created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "sticker name")
@@ -89,14 +92,14 @@ func NewStickersStringCreateCmd(resolvePath func() (string, error), outputJSON f
 }
 
 // NewStickersStringUpdateCmd returns the "stickers string update" command.
-func NewStickersStringUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringUpdateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a string sticker",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -110,14 +113,10 @@ func NewStickersStringUpdateCmd(resolvePath func() (string, error), outputJSON f
 				return fmt.Errorf("update string sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update string sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update string sticker", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "String sticker updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("String sticker updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "sticker name")
@@ -125,13 +124,13 @@ func NewStickersStringUpdateCmd(resolvePath func() (string, error), outputJSON f
 }
 
 // NewStickersStringStatesListCmd returns the "stickers string states list" command (states from sticker get).
-func NewStickersStringStatesListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringStatesListCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list [sticker-id]",
 		Short: "List states of a string sticker",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -141,34 +140,31 @@ func NewStickersStringStatesListCmd(resolvePath func() (string, error), outputJS
 				return fmt.Errorf("get string sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 || resp.JSON200 == nil {
-				return fmt.Errorf("get string sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get string sticker", resp.HTTPResponse, resp.Body)
 			}
 			states := resp.JSON200.States
 			if states == nil {
 				states = &[]client.StringStickerStateDto{}
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, states)
-			}
 			headers := []string{"ID", "Name"}
 			rows := make([][]string, 0, len(*states))
 			for _, s := range *states {
 				rows = append(rows, []string{s.Id, s.Name})
 			}
-			return output.PrintTable(out, headers, rows)
+			return g.print(out, output.Result{Value: states, Items: states, Table: output.NewTable(headers, rows)})
 		},
 	}
 }
 
 // NewStickersStringStatesGetCmd returns the "stickers string states get" command.
-func NewStickersStringStatesGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringStatesGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [sticker-id] [state-id]",
 		Short: "Get a string sticker state by ID",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -178,22 +174,19 @@ func NewStickersStringStatesGetCmd(resolvePath func() (string, error), outputJSO
 				return fmt.Errorf("get string sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get string sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get string sticker state", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get string sticker state: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			return output.PrintJSON(out, resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewStickersStringStatesCreateCmd returns the "stickers string states create" command.
-func NewStickersStringStatesCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringStatesCreateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "create [sticker-id]",
@@ -203,7 +196,7 @@ func NewStickersStringStatesCreateCmd(resolvePath func() (string, error), output
 			if name == "" {
 				return fmt.Errorf("name is required (--name)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -214,17 +207,13 @@ func NewStickersStringStatesCreateCmd(resolvePath func() (string, error), output
 				return fmt.Errorf("create string sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create string sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create string sticker state", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "String sticker state created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("String sticker state created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "state name")
@@ -233,14 +222,14 @@ func NewStickersStringStatesCreateCmd(resolvePath func() (string, error), output
 }
 
 // NewStickersStringStatesUpdateCmd returns the "stickers string states update" command.
-func NewStickersStringStatesUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringStatesUpdateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "update [sticker-id] [state-id]",
 		Short: "Update a string sticker state",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -254,14 +243,10 @@ func NewStickersStringStatesUpdateCmd(resolvePath func() (string, error), output
 				return fmt.Errorf("update string sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update string sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update string sticker state", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "String sticker state updated: id=%s\n", stateID)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("String sticker state updated: id=%s", stateID)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "state name")
@@ -269,13 +254,13 @@ func NewStickersStringStatesUpdateCmd(resolvePath func() (string, error), output
 }
 
 // NewStickersStringGetCmd returns the "stickers string get" command.
-func NewStickersStringGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersStringGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
 		Short: "Get string sticker by ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -285,63 +270,67 @@ func NewStickersStringGetCmd(resolvePath func() (string, error), outputJSON func
 				return fmt.Errorf("get string sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get string sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get string sticker", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get string sticker: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			return output.PrintJSON(out, resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewStickersSprintListCmd returns the "stickers sprint list" command.
-func NewStickersSprintListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var includeDeleted bool
+func NewStickersSprintListCmd(g *Globals) *cobra.Command {
+	var limit, offset int
+	var all, includeDeleted bool
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List sprint stickers",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
-			params := &client.SprintStickerControllerSearchParams{}
+			params := client.SprintStickerControllerSearchParams{}
 			if includeDeleted {
 				params.IncludeDeleted = boolPtr(true)
 			}
-			resp, err := api.SprintStickerControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list sprint stickers: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list sprint stickers: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list sprint stickers: empty response")
-			}
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Name"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, s := range resp.JSON200.Content {
-				rows = append(rows, []string{s.Id, s.Name})
-			}
-			return output.PrintTable(out, headers, rows)
+			fetch := func(ctx context.Context, limit, offset int) ([]client.SprintStickerWithStatesListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.SprintStickerControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list sprint stickers: %w", err)
+				}
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list sprint stickers", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list sprint stickers: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(stickers []client.SprintStickerWithStatesListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Name"}
+				rows := make([][]string, 0, len(stickers))
+				for _, s := range stickers {
+					rows = append(rows, []string{s.Id, s.Name})
+				}
+				return output.Result{Value: client.SprintStickerWithStatesListDto{Content: stickers, Paging: paging}, Items: stickers, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
+	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
+	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().BoolVar(&includeDeleted, "include-deleted", false, "include deleted stickers")
 	return c
 }
 
 // NewStickersSprintCreateCmd returns the "stickers sprint create" command.
-func NewStickersSprintCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintCreateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "create",
@@ -350,7 +339,7 @@ func NewStickersSprintCreateCmd(resolvePath func() (string, error), outputJSON f
 			if name == "" {
 				return fmt.Errorf("name is required (--name)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -360,17 +349,13 @@ func NewStickersSprintCreateCmd(resolvePath func() (string, error), outputJSON f
 				return fmt.Errorf("create sprint sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create sprint sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create sprint sticker", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Sprint sticker created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Sprint sticker created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "sticker name")
@@ -379,14 +364,14 @@ func NewStickersSprintCreateCmd(resolvePath func() (string, error), outputJSON f
 }
 
 // NewStickersSprintUpdateCmd returns the "stickers sprint update" command.
-func NewStickersSprintUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintUpdateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a sprint sticker",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -400,14 +385,10 @@ func NewStickersSprintUpdateCmd(resolvePath func() (string, error), outputJSON f
 				return fmt.Errorf("update sprint sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update sprint sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update sprint sticker", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Sprint sticker updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Sprint sticker updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "sticker name")
@@ -415,13 +396,13 @@ func NewStickersSprintUpdateCmd(resolvePath func() (string, error), outputJSON f
 }
 
 // NewStickersSprintStatesListCmd returns the "stickers sprint states list" command.
-func NewStickersSprintStatesListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintStatesListCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list [sticker-id]",
 		Short: "List states of a sprint sticker",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -431,34 +412,31 @@ func NewStickersSprintStatesListCmd(resolvePath func() (string, error), outputJS
 				return fmt.Errorf("get sprint sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 || resp.JSON200 == nil {
-				return fmt.Errorf("get sprint sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get sprint sticker", resp.HTTPResponse, resp.Body)
 			}
 			states := resp.JSON200.States
 			if states == nil {
 				states = &[]client.SprintStickerStateDto{}
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, states)
-			}
 			headers := []string{"ID", "Name"}
 			rows := make([][]string, 0, len(*states))
 			for _, s := range *states {
 				rows = append(rows, []string{s.Id, s.Name})
 			}
-			return output.PrintTable(out, headers, rows)
+			return g.print(out, output.Result{Value: states, Items: states, Table: output.NewTable(headers, rows)})
 		},
 	}
 }
 
 // NewStickersSprintStatesGetCmd returns the "stickers sprint states get" command.
-func NewStickersSprintStatesGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintStatesGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [sticker-id] [state-id]",
 		Short: "Get a sprint sticker state by ID",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -468,22 +446,19 @@ func NewStickersSprintStatesGetCmd(resolvePath func() (string, error), outputJSO
 				return fmt.Errorf("get sprint sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get sprint sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get sprint sticker state", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get sprint sticker state: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			return output.PrintJSON(out, resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewStickersSprintStatesCreateCmd returns the "stickers sprint states create" command.
-func NewStickersSprintStatesCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintStatesCreateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "create [sticker-id]",
@@ -493,7 +468,7 @@ func NewStickersSprintStatesCreateCmd(resolvePath func() (string, error), output
 			if name == "" {
 				return fmt.Errorf("name is required (--name)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -504,17 +479,13 @@ func NewStickersSprintStatesCreateCmd(resolvePath func() (string, error), output
 				return fmt.Errorf("create sprint sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create sprint sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create sprint sticker state", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Sprint sticker state created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Sprint sticker state created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "state name")
@@ -523,14 +494,14 @@ func NewStickersSprintStatesCreateCmd(resolvePath func() (string, error), output
 }
 
 // NewStickersSprintStatesUpdateCmd returns the "stickers sprint states update" command.
-func NewStickersSprintStatesUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintStatesUpdateCmd(g *Globals) *cobra.Command {
 	var name string
 	c := &cobra.Command{
 		Use:   "update [sticker-id] [state-id]",
 		Short: "Update a sprint sticker state",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -544,14 +515,10 @@ func NewStickersSprintStatesUpdateCmd(resolvePath func() (string, error), output
 				return fmt.Errorf("update sprint sticker state: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update sprint sticker state: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update sprint sticker state", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Sprint sticker state updated: id=%s\n", stateID)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Sprint sticker state updated: id=%s", stateID)})
 		},
 	}
 	c.Flags().StringVar(&name, "name", "", "state name")
@@ -559,13 +526,13 @@ func NewStickersSprintStatesUpdateCmd(resolvePath func() (string, error), output
 }
 
 // NewStickersSprintGetCmd returns the "stickers sprint get" command.
-func NewStickersSprintGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersSprintGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
 		Short: "Get sprint sticker by ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -575,48 +542,45 @@ func NewStickersSprintGetCmd(resolvePath func() (string, error), outputJSON func
 				return fmt.Errorf("get sprint sticker: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get sprint sticker: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get sprint sticker", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get sprint sticker: empty response")
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			return output.PrintJSON(out, resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewStickersCmd returns the "stickers" parent command.
-func NewStickersCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewStickersCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "stickers",
 		Short: "String and sprint stickers",
 	}
 	stringCmd := &cobra.Command{Use: "string", Short: "String stickers"}
-	stringCmd.AddCommand(NewStickersStringListCmd(resolvePath, outputJSON))
-	stringCmd.AddCommand(NewStickersStringGetCmd(resolvePath, outputJSON))
-	stringCmd.AddCommand(NewStickersStringCreateCmd(resolvePath, outputJSON))
-	stringCmd.AddCommand(NewStickersStringUpdateCmd(resolvePath, outputJSON))
+	stringCmd.AddCommand(NewStickersStringListCmd(g))
+	stringCmd.AddCommand(NewStickersStringGetCmd(g))
+	stringCmd.AddCommand(NewStickersStringCreateCmd(g))
+	stringCmd.AddCommand(NewStickersStringUpdateCmd(g))
 	stringStates := &cobra.Command{Use: "states", Short: "String sticker states"}
-	stringStates.AddCommand(NewStickersStringStatesListCmd(resolvePath, outputJSON))
-	stringStates.AddCommand(NewStickersStringStatesGetCmd(resolvePath, outputJSON))
-	stringStates.AddCommand(NewStickersStringStatesCreateCmd(resolvePath, outputJSON))
-	stringStates.AddCommand(NewStickersStringStatesUpdateCmd(resolvePath, outputJSON))
+	stringStates.AddCommand(NewStickersStringStatesListCmd(g))
+	stringStates.AddCommand(NewStickersStringStatesGetCmd(g))
+	stringStates.AddCommand(NewStickersStringStatesCreateCmd(g))
+	stringStates.AddCommand(NewStickersStringStatesUpdateCmd(g))
 	stringCmd.AddCommand(stringStates)
 	c.AddCommand(stringCmd)
 	sprintCmd := &cobra.Command{Use: "sprint", Short: "Sprint stickers"}
-	sprintCmd.AddCommand(NewStickersSprintListCmd(resolvePath, outputJSON))
-	sprintCmd.AddCommand(NewStickersSprintGetCmd(resolvePath, outputJSON))
-	sprintCmd.AddCommand(NewStickersSprintCreateCmd(resolvePath, outputJSON))
-	sprintCmd.AddCommand(NewStickersSprintUpdateCmd(resolvePath, outputJSON))
+	sprintCmd.AddCommand(NewStickersSprintListCmd(g))
+	sprintCmd.AddCommand(NewStickersSprintGetCmd(g))
+	sprintCmd.AddCommand(NewStickersSprintCreateCmd(g))
+	sprintCmd.AddCommand(NewStickersSprintUpdateCmd(g))
 	sprintStates := &cobra.Command{Use: "states", Short: "Sprint sticker states"}
-	sprintStates.AddCommand(NewStickersSprintStatesListCmd(resolvePath, outputJSON))
-	sprintStates.AddCommand(NewStickersSprintStatesGetCmd(resolvePath, outputJSON))
-	sprintStates.AddCommand(NewStickersSprintStatesCreateCmd(resolvePath, outputJSON))
-	sprintStates.AddCommand(NewStickersSprintStatesUpdateCmd(resolvePath, outputJSON))
+	sprintStates.AddCommand(NewStickersSprintStatesListCmd(g))
+	sprintStates.AddCommand(NewStickersSprintStatesGetCmd(g))
+	sprintStates.AddCommand(NewStickersSprintStatesCreateCmd(g))
+	sprintStates.AddCommand(NewStickersSprintStatesUpdateCmd(g))
 	sprintCmd.AddCommand(sprintStates)
 	c.AddCommand(sprintCmd)
 	return c
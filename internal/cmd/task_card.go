@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// taskCard is the human view of a task for "tasks get", with IDs resolved to names.
+type taskCard struct {
+	task      *client.TaskDto
+	path      []string // project, board and column titles
+	assignees []string
+	createdBy string
+	stickers  []cardField
+	subtasks  []cardSubtask
+}
+
+type cardField struct {
+	name, value string
+}
+
+type cardSubtask struct {
+	title, ref string
+	completed  bool
+}
+
+// buildTaskCard resolves the task's column, users, stickers and subtasks.
+// With a nil n (--raw-ids) the card shows IDs; subtasks are still fetched
+// for their titles.
+func buildTaskCard(ctx context.Context, api *client.ClientWithResponses, n *names, t *client.TaskDto) taskCard {
+	c := taskCard{task: t}
+	if t.ColumnId != nil && *t.ColumnId != "" {
+		c.path = n.columnPath(*t.ColumnId)
+	}
+	if t.Assigned != nil {
+		c.assignees = n.userList(*t.Assigned)
+	}
+	if t.CreatedBy != nil && *t.CreatedBy != "" {
+		c.createdBy = n.user(*t.CreatedBy)
+	}
+	if t.Stickers != nil {
+		for id, value := range *t.Stickers {
+			name, state := n.sticker(id, value)
+			c.stickers = append(c.stickers, cardField{name: name, value: state})
+		}
+		sort.Slice(c.stickers, func(i, j int) bool {
+			if c.stickers[i].name != c.stickers[j].name {
+				return c.stickers[i].name < c.stickers[j].name
+			}
+			return c.stickers[i].value < c.stickers[j].value
+		})
+	}
+	if t.Subtasks != nil {
+		for _, id := range *t.Subtasks {
+			st := cardSubtask{title: id}
+			if resp, err := api.TaskControllerGetWithResponse(ctx, id); err == nil && resp.HTTPResponse.StatusCode == http.StatusOK && resp.JSON200 != nil {
+				st.title = resp.JSON200.Title
+				st.completed = resp.JSON200.Completed != nil && *resp.JSON200.Completed
+				if resp.JSON200.IdTaskCommon != nil {
+					st.ref = *resp.JSON200.IdTaskCommon
+				}
+			}
+			c.subtasks = append(c.subtasks, st)
+		}
+	}
+	return c
+}
+
+func (c taskCard) String() string {
+	t := c.task
+	var b strings.Builder
+	b.WriteString(t.Title + "\n")
+	ref := t.Id
+	if t.IdTaskCommon != nil && *t.IdTaskCommon != "" {
+		ref = *t.IdTaskCommon + " · " + t.Id
+	}
+	b.WriteString(ref + "\n\n")
+
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-10s %s\n", label+":", value)
+		}
+	}
+	field("Path", strings.Join(c.path, " / "))
+	field("Status", taskStatus(t))
+	field("Assigned", strings.Join(c.assignees, ", "))
+	if d := t.Deadline; d != nil {
+		withTime := d.WithTime == nil || *d.WithTime
+		field("Deadline", formatDate(d.Deadline, withTime))
+		if d.StartDate != nil {
+			field("Start", formatDate(*d.StartDate, withTime))
+		}
+	}
+	if tt := t.TimeTracking; tt != nil && (tt.Plan != 0 || tt.Work != 0) {
+		field("Time", formatTimeTracking(tt))
+	}
+	for i, s := range c.stickers {
+		label := "Stickers:"
+		if i > 0 {
+			label = ""
+		}
+		fmt.Fprintf(&b, "%-10s %s: %s\n", label, s.name, s.value)
+	}
+	created := formatTimestamp(int64(t.Timestamp))
+	if c.createdBy != "" {
+		created += " by " + c.createdBy
+	}
+	field("Created", strings.TrimSpace(created))
+	if t.Color != nil {
+		field("Color", *t.Color)
+	}
+
+	if t.Checklists != nil {
+		for _, cl := range *t.Checklists {
+			done := 0
+			for _, item := range cl.Items {
+				if item.IsCompleted {
+					done++
+				}
+			}
+			fmt.Fprintf(&b, "\nChecklist: %s (%d/%d)\n", cl.Title, done, len(cl.Items))
+			for _, item := range cl.Items {
+				fmt.Fprintf(&b, "  %s %s\n", checkbox(item.IsCompleted), item.Title)
+			}
+		}
+	}
+	if len(c.subtasks) > 0 {
+		done := 0
+		for _, st := range c.subtasks {
+			if st.completed {
+				done++
+			}
+		}
+		fmt.Fprintf(&b, "\nSubtasks (%d/%d):\n", done, len(c.subtasks))
+		for _, st := range c.subtasks {
+			line := fmt.Sprintf("  %s %s", checkbox(st.completed), st.title)
+			if st.ref != "" {
+				line += " (" + st.ref + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if t.Description != nil {
+		if text := htmlToText(*t.Description); text != "" {
+			b.WriteString("\nDescription:\n")
+			for _, line := range strings.Split(text, "\n") {
+				b.WriteString(strings.TrimRight("  "+line, " ") + "\n")
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// taskStatus summarizes the completed, archived and deleted flags.
+func taskStatus(t *client.TaskDto) string {
+	var s []string
+	if t.Completed != nil && *t.Completed {
+		s = append(s, "completed")
+		if t.CompletedTimestamp != nil {
+			s[len(s)-1] += " " + formatTimestamp(int64(*t.CompletedTimestamp))
+		}
+	} else {
+		s = append(s, "open")
+	}
+	if t.Archived != nil && *t.Archived {
+		s = append(s, "archived")
+	}
+	if t.Deleted != nil && *t.Deleted {
+		s = append(s, "deleted")
+	}
+	return strings.Join(s, ", ")
+}
+
+// formatDate formats ms in local time, without the clock when withTime is false.
+func formatDate(ms int64, withTime bool) string {
+	if withTime {
+		return formatTimestamp(ms)
+	}
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Local().Format("2006-01-02")
+}
+
+// formatTimeTracking shows work against plan (hours), e.g. "2h of 5h planned (40%)".
+func formatTimeTracking(tt *client.TimeTracking) string {
+	if tt.Plan == 0 {
+		return fmt.Sprintf("%gh worked, no plan", tt.Work)
+	}
+	return fmt.Sprintf("%gh of %gh planned (%.0f%%)", tt.Work, tt.Plan, 100*tt.Work/tt.Plan)
+}
+
+func checkbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+var (
+	htmlBreak = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6]|tr)>`)
+	htmlItem  = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+	blankRuns = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText turns a task description (HTML from the YouGile editor) into plain text.
+func htmlToText(s string) string {
+	s = htmlBreak.ReplaceAllString(s, "\n")
+	s = htmlItem.ReplaceAllString(s, "- ")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+	s = blankRuns.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTaskGetCmd_Table_RendersCardWithNames(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := map[string]string{
+			"/api-v2/tasks/t1": `{"id":"t1","title":"Купить молоко","idTaskCommon":"ID-1","columnId":"c1","assigned":["u1","gone"],
+				"deadline":{"deadline":1790000000000,"withTime":false,"blockedPoints":[],"links":[]},
+				"timeTracking":{"plan":4,"work":1},"stickers":{"s1":"st1"},"subtasks":["t2"],
+				"checklists":[{"title":"Шаги","items":[{"title":"a","isCompleted":true},{"title":"b","isCompleted":false}]}],
+				"description":"<p>Line&nbsp;one</p><ul><li>x</li></ul>","timestamp":0}`,
+			"/api-v2/tasks/t2":        `{"id":"t2","title":"Sub","completed":true,"idTaskCommon":"ID-2","timestamp":0}`,
+			"/api-v2/columns":         `{"content":[{"id":"c1","title":"Todo","boardId":"b1"}],"paging":{}}`,
+			"/api-v2/boards":          `{"content":[{"id":"b1","title":"Main","projectId":"p1"}],"paging":{}}`,
+			"/api-v2/projects":        `{"content":[{"id":"p1","title":"Proj","timestamp":0}],"paging":{}}`,
+			"/api-v2/users":           `{"content":[{"id":"u1","email":"ivan@x.io","realName":"Иван"}],"paging":{}}`,
+			"/api-v2/string-stickers": `{"content":[{"id":"s1","name":"Priority","states":[{"id":"st1","name":"High"}]}],"paging":{}}`,
+			"/api-v2/sprint-stickers": `{"content":[],"paging":{}}`,
+		}[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	c := NewTaskGetCmd(statusGlobals(t, srv.URL))
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetArgs([]string{"t1"})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"ID-1 · t1",
+		"Path:      Proj / Main / Todo",
+		"Assigned:  Иван, gone",
+		"Deadline:  2026-09-2", // date only: withTime is false
+		"Time:      1h of 4h planned (25%)",
+		"Stickers:  Priority: High",
+		"Checklist: Шаги (1/2)\n  [x] a\n  [ ] b",
+		"Subtasks (1/1):\n  [x] Sub (ID-2)",
+		"Description:\n  Line one\n  - x",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("card missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Deadline:  2026-09-21 ") {
+		t.Errorf("date-only deadline shows a time:\n%s", out)
+	}
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// parseChecklist parses a --checklist value: "Title: item; [x] done item".
+// Items are separated by ";"; a leading "[x]" marks an item completed.
+func parseChecklist(s string) (client.CheckList, error) {
+	title, rest, _ := strings.Cut(s, ":")
+	cl := client.CheckList{Title: strings.TrimSpace(title), Items: []client.CheckListItem{}}
+	if cl.Title == "" {
+		return cl, fmt.Errorf("checklist %q: want \"Title: item 1; item 2\"", s)
+	}
+	for _, item := range strings.Split(rest, ";") {
+		item = strings.TrimSpace(item)
+		done := false
+		if lower := strings.ToLower(item); strings.HasPrefix(lower, "[x]") || strings.HasPrefix(lower, "[ ]") {
+			done = lower[1] == 'x'
+			item = strings.TrimSpace(item[3:])
+		}
+		if item != "" {
+			cl.Items = append(cl.Items, client.CheckListItem{Title: item, IsCompleted: done})
+		}
+	}
+	return cl, nil
+}
+
+// parseStickers parses --sticker values "Sticker=Value" into the task stickers
+// map. Stickers and their states are given by ID or name; the value of a
+// sticker without states (a free text or number field) is sent as is.
+func parseStickers(n *names, values []string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for _, v := range values {
+		ref, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("sticker %q: want Sticker=Value", v)
+		}
+		id, err := n.resolveSticker(strings.TrimSpace(ref))
+		if err != nil {
+			return nil, err
+		}
+		value = strings.TrimSpace(value)
+		if st, ok := n.stickers[id]; ok && len(st.States) > 0 && value != "-" && value != "empty" {
+			if value, err = n.resolveStickerState(id, value); err != nil {
+				return nil, err
+			}
+		}
+		out[id] = value
+	}
+	return out, nil
+}
+
+// parseKeyValues parses "key=value" pairs, e.g. CRM custom fields.
+func parseKeyValues(flag string, values []string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("--%s %q: want key=value", flag, v)
+		}
+		out[strings.TrimSpace(key)] = value
+	}
+	return out, nil
+}
+
+// parseHours parses a number of hours such as 1.5 or "90m".
+func parseHours(s string) (float32, error) {
+	if h, err := strconv.ParseFloat(strings.TrimSpace(s), 32); err == nil && h >= 0 {
+		return float32(h), nil
+	}
+	if d, err := time.ParseDuration(strings.TrimSpace(s)); err == nil && d >= 0 {
+		return float32(d.Hours()), nil
+	}
+	return 0, fmt.Errorf("invalid hours %q (want e.g. 1.5, 90m or 2h30m)", s)
+}
+
+// deadlineFlags are the --deadline, --start and --clear-deadline flags of
+// tasks create and update.
+type deadlineFlags struct {
+	deadline, start string
+	clear           bool
+}
+
+func (f *deadlineFlags) register(c *cobra.Command, withClear bool) {
+	c.Flags().StringVar(&f.deadline, "deadline", "", `deadline, e.g. 2026-11-01, "2026-11-01 18:00", tomorrow, +3d, "next friday"`)
+	c.Flags().StringVar(&f.start, "start", "", "start date, same forms as --deadline")
+	if withClear {
+		c.Flags().BoolVar(&f.clear, "clear-deadline", false, "remove the deadline")
+		c.MarkFlagsMutuallyExclusive("clear-deadline", "deadline")
+		c.MarkFlagsMutuallyExclusive("clear-deadline", "start")
+	}
+}
+
+// parse returns the given dates as YouGile timestamps (nil when not given)
+// and whether any of them has a time of day.
+func (f *deadlineFlags) parse() (deadline, start *int64, withTime bool, err error) {
+	for _, d := range []struct {
+		flag, value string
+		ts          **int64
+	}{{"deadline", f.deadline, &deadline}, {"start", f.start, &start}} {
+		if d.value == "" {
+			continue
+		}
+		t, hasTime, err := parseDateTime(d.value)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("--%s: %w", d.flag, err)
+		}
+		ms := t.UnixMilli()
+		*d.ts = &ms
+		withTime = withTime || hasTime
+	}
+	return deadline, start, withTime, nil
+}
+
+// createDeadline returns the deadline of a new task, or nil.
+func (f *deadlineFlags) createDeadline() (*client.Deadline, error) {
+	deadline, start, withTime, err := f.parse()
+	if err != nil || (deadline == nil && start == nil) {
+		return nil, err
+	}
+	if deadline == nil {
+		return nil, fmt.Errorf("--start needs --deadline")
+	}
+	return &client.Deadline{Deadline: *deadline, StartDate: start, WithTime: &withTime, BlockedPoints: []string{}, Links: []string{}}, nil
+}
+
+// updateDeadline returns the deadline change for the task, or nil. The
+// deadline object is replaced as a whole, so the date not given, links and
+// blocked points are copied from cur (the task's current deadline, or nil).
+func (f *deadlineFlags) updateDeadline(cur *client.Deadline) (*client.UpdateDeadline, error) {
+	if f.clear {
+		return &client.UpdateDeadline{Deleted: boolPtr(true), BlockedPoints: []string{}, Links: []string{}}, nil
+	}
+	deadline, start, withTime, err := f.parse()
+	if err != nil || (deadline == nil && start == nil) {
+		return nil, err
+	}
+	ud := &client.UpdateDeadline{Deadline: deadline, StartDate: start, BlockedPoints: []string{}, Links: []string{}}
+	if cur != nil {
+		kept := false
+		if ud.Deadline == nil && cur.Deadline != 0 {
+			ud.Deadline, kept = &cur.Deadline, true
+		}
+		if ud.StartDate == nil && cur.StartDate != nil {
+			ud.StartDate, kept = cur.StartDate, true
+		}
+		withTime = withTime || (kept && isSet(cur.WithTime))
+		if cur.BlockedPoints != nil {
+			ud.BlockedPoints = cur.BlockedPoints
+		}
+		if cur.Links != nil {
+			ud.Links = cur.Links
+		}
+	}
+	if ud.Deadline == nil {
+		return nil, fmt.Errorf("--start needs --deadline")
+	}
+	ud.WithTime = &withTime
+	return ud, nil
+}
+
+// getTask fetches the task with the given ID.
+func getTask(ctx context.Context, api *client.ClientWithResponses, id string) (*client.TaskDto, error) {
+	resp, err := api.TaskControllerGetWithResponse(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	if resp.HTTPResponse.StatusCode != 200 {
+		return nil, apiError("get task", resp.HTTPResponse, resp.Body)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("get task: empty response")
+	}
+	return resp.JSON200, nil
+}
+
+// editTaskField does a read-modify-write of a task field that the API only
+// replaces as a whole, such as checklists. Just before the write the task is
+// read again, and if the field changed meanwhile (e.g. someone edited it in
+// the browser) nothing is written. It returns the new value.
+func editTaskField[T any](ctx context.Context, api *client.ClientWithResponses, id, field string,
+	get func(*client.TaskDto) T, edit func(T) (T, error), set func(*client.UpdateTaskDto, T)) (T, error) {
+	var zero T
+	task, err := getTask(ctx, api, id)
+	if err != nil {
+		return zero, err
+	}
+	before, _ := json.Marshal(get(task))
+	value, err := edit(get(task))
+	if err != nil {
+		return zero, err
+	}
+
+	if task, err = getTask(ctx, api, id); err != nil {
+		return zero, err
+	}
+	if now, _ := json.Marshal(get(task)); !bytes.Equal(before, now) {
+		return zero, fmt.Errorf("task %s: %s changed while editing, nothing was written; run the command again", id, field)
+	}
+	var body client.UpdateTaskDto
+	set(&body, value)
+	resp, err := api.TaskControllerUpdateWithResponse(ctx, id, body)
+	if err != nil {
+		return zero, fmt.Errorf("update task: %w", err)
+	}
+	if resp.HTTPResponse.StatusCode != 200 {
+		return zero, apiError("update task", resp.HTTPResponse, resp.Body)
+	}
+	return value, nil
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// Checklist items are given as "list.item" numbers as shown by "tasks
+// checklist list" (e.g. 2.3), as a number within the checklist chosen with
+// --list (or the task's only checklist), or by title.
+
+var checklistItemNumber = regexp.MustCompile(`^(\d+)\.(\d+)$`)
+
+// checklistIndex finds a checklist by title (case-insensitive) or number.
+func checklistIndex(lists []client.CheckList, ref string) (int, error) {
+	for i, cl := range lists {
+		if strings.EqualFold(strings.TrimSpace(cl.Title), strings.TrimSpace(ref)) {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(lists) {
+		return n - 1, nil
+	}
+	return 0, fmt.Errorf("checklist %q not found", ref)
+}
+
+// defaultChecklist returns the checklist chosen with --list, or the task's
+// only checklist.
+func defaultChecklist(lists []client.CheckList, list string) (int, error) {
+	if list != "" {
+		return checklistIndex(lists, list)
+	}
+	switch len(lists) {
+	case 0:
+		return 0, fmt.Errorf("the task has no checklist; name one with --list")
+	case 1:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("the task has %d checklists; choose one with --list", len(lists))
+	}
+}
+
+// checklistItem finds an item (see above); list is the --list value.
+func checklistItem(lists []client.CheckList, list, ref string) (li, ii int, err error) {
+	ref = strings.TrimSpace(ref)
+	if m := checklistItemNumber.FindStringSubmatch(ref); m != nil && list == "" {
+		li, _ = strconv.Atoi(m[1])
+		ii, _ = strconv.Atoi(m[2])
+		if li < 1 || li > len(lists) || ii < 1 || ii > len(lists[li-1].Items) {
+			return 0, 0, fmt.Errorf("checklist item %s not found", ref)
+		}
+		return li - 1, ii - 1, nil
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if li, err = defaultChecklist(lists, list); err != nil {
+			return 0, 0, fmt.Errorf("item %s: %w", ref, err)
+		}
+		if n < 1 || n > len(lists[li].Items) {
+			return 0, 0, fmt.Errorf("checklist %q has no item %d", lists[li].Title, n)
+		}
+		return li, n - 1, nil
+	}
+
+	scope := make([]int, 0, len(lists))
+	if list != "" {
+		if li, err = checklistIndex(lists, list); err != nil {
+			return 0, 0, err
+		}
+		scope = append(scope, li)
+	} else {
+		for i := range lists {
+			scope = append(scope, i)
+		}
+	}
+	var found [][2]int
+	for _, l := range scope {
+		for i, item := range lists[l].Items {
+			if strings.EqualFold(strings.TrimSpace(item.Title), ref) {
+				found = append(found, [2]int{l, i})
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return 0, 0, fmt.Errorf("checklist item %q not found", ref)
+	case 1:
+		return found[0][0], found[0][1], nil
+	}
+	nums := make([]string, len(found))
+	for i, f := range found {
+		nums[i] = fmt.Sprintf("%d.%d", f[0]+1, f[1]+1)
+	}
+	return 0, 0, fmt.Errorf("checklist item %q is ambiguous, use its number: %s", ref, strings.Join(nums, ", "))
+}
+
+// checklistTable lays out the checklists with their item numbers.
+func checklistTable(lists []client.CheckList) *output.Table {
+	rows := [][]string{}
+	for li, cl := range lists {
+		for ii, item := range cl.Items {
+			rows = append(rows, []string{fmt.Sprintf("%d.%d", li+1, ii+1), cl.Title, checkbox(item.IsCompleted), item.Title})
+		}
+		if len(cl.Items) == 0 {
+			rows = append(rows, []string{strconv.Itoa(li + 1), cl.Title, "", ""})
+		}
+	}
+	return output.NewTable([]string{"#", "Checklist", "Done", "Item"}, rows)
+}
+
+// checklistEdit is the shared body of the checklist subcommands that change
+// the task: it resolves the task, applies edit with a safe read-modify-write
+// and prints the result.
+func checklistEdit(g *Globals, c *cobra.Command, task string, edit func([]client.CheckList) ([]client.CheckList, string, error)) error {
+	_, s, api, err := loadSession(g)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	id, err := g.newNames(ctx, api, s).resolveTask(task)
+	if err != nil {
+		return err
+	}
+	var msg string
+	lists, err := editTaskField(ctx, api, id, "checklists",
+		func(t *client.TaskDto) []client.CheckList {
+			if t.Checklists == nil {
+				return []client.CheckList{}
+			}
+			return *t.Checklists
+		},
+		func(lists []client.CheckList) ([]client.CheckList, error) {
+			var err error
+			lists, msg, err = edit(lists)
+			return lists, err
+		},
+		func(body *client.UpdateTaskDto, lists []client.CheckList) { body.Checklists = &lists })
+	if err != nil {
+		return err
+	}
+	return g.print(c.OutOrStdout(), output.Result{Value: lists, Message: fmt.Sprintf("%s (task %s)", msg, task)})
+}
+
+// NewTasksChecklistListCmd returns the "tasks checklist list" command.
+func NewTasksChecklistListCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <task>",
+		Short: "List a task's checklists with item numbers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			task, err := getTask(ctx, api, id)
+			if err != nil {
+				return err
+			}
+			lists := []client.CheckList{}
+			if task.Checklists != nil {
+				lists = *task.Checklists
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: lists, Items: lists, Table: checklistTable(lists)})
+		},
+	}
+}
+
+// NewTasksChecklistAddCmd returns the "tasks checklist add" command.
+func NewTasksChecklistAddCmd(g *Globals) *cobra.Command {
+	var list string
+	var done bool
+	c := &cobra.Command{
+		Use:   "add <task> <item>...",
+		Short: "Add items to a checklist",
+		Long: `Add items to the checklist named with --list, or to the task's only
+checklist. A --list that does not exist yet is created.`,
+		Example: `  yougile tasks checklist add ID-123 --list Release "Tag the build" "Publish notes"`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return checklistEdit(g, c, args[0], func(lists []client.CheckList) ([]client.CheckList, string, error) {
+				li, err := defaultChecklist(lists, list)
+				if err != nil {
+					if list == "" {
+						return nil, "", err
+					}
+					lists = append(lists, client.CheckList{Title: list, Items: []client.CheckListItem{}})
+					li = len(lists) - 1
+				}
+				for _, title := range args[1:] {
+					lists[li].Items = append(lists[li].Items, client.CheckListItem{Title: title, IsCompleted: done})
+				}
+				return lists, fmt.Sprintf("Added %d item(s) to %q", len(args)-1, lists[li].Title), nil
+			})
+		},
+	}
+	c.Flags().StringVar(&list, "list", "", "checklist title or number (created if missing)")
+	c.Flags().BoolVar(&done, "done", false, "add the items already checked")
+	return c
+}
+
+// newTasksChecklistMarkCmd returns "tasks checklist check" or "uncheck".
+func newTasksChecklistMarkCmd(g *Globals, use, short string, done bool) *cobra.Command {
+	var list string
+	c := &cobra.Command{
+		Use:   use + " <task> <item>...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			return checklistEdit(g, c, args[0], func(lists []client.CheckList) ([]client.CheckList, string, error) {
+				for _, ref := range args[1:] {
+					li, ii, err := checklistItem(lists, list, ref)
+					if err != nil {
+						return nil, "", err
+					}
+					lists[li].Items[ii].IsCompleted = done
+				}
+				return lists, fmt.Sprintf("%sed %d item(s)", strings.ToUpper(use[:1])+use[1:], len(args)-1), nil
+			})
+		},
+	}
+	c.Flags().StringVar(&list, "list", "", "checklist title or number")
+	return c
+}
+
+// NewTasksChecklistRenameCmd returns the "tasks checklist rename" command.
+func NewTasksChecklistRenameCmd(g *Globals) *cobra.Command {
+	var list string
+	c := &cobra.Command{
+		Use:   "rename <task> [item] <title>",
+		Short: "Rename a checklist item, or with --list and no item the checklist",
+		Example: `  yougile tasks checklist rename ID-123 1.2 "Tag the release build"
+  yougile tasks checklist rename ID-123 --list Release "Release 2.0"`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(c *cobra.Command, args []string) error {
+			return checklistEdit(g, c, args[0], func(lists []client.CheckList) ([]client.CheckList, string, error) {
+				title := args[len(args)-1]
+				if len(args) == 2 {
+					if list == "" {
+						return nil, "", fmt.Errorf("give the item to rename, or --list to rename a checklist")
+					}
+					li, err := checklistIndex(lists, list)
+					if err != nil {
+						return nil, "", err
+					}
+					lists[li].Title = title
+					return lists, fmt.Sprintf("Checklist renamed to %q", title), nil
+				}
+				li, ii, err := checklistItem(lists, list, args[1])
+				if err != nil {
+					return nil, "", err
+				}
+				lists[li].Items[ii].Title = title
+				return lists, fmt.Sprintf("Item renamed to %q", title), nil
+			})
+		},
+	}
+	c.Flags().StringVar(&list, "list", "", "checklist title or number")
+	return c
+}
+
+// NewTasksChecklistRemoveCmd returns the "tasks checklist remove" command.
+func NewTasksChecklistRemoveCmd(g *Globals) *cobra.Command {
+	var list string
+	c := &cobra.Command{
+		Use:   "remove <task> [item...]",
+		Short: "Remove checklist items, or with --list and no items the whole checklist",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return checklistEdit(g, c, args[0], func(lists []client.CheckList) ([]client.CheckList, string, error) {
+				if len(args) == 1 {
+					if list == "" {
+						return nil, "", fmt.Errorf("give the items to remove, or --list to remove a checklist")
+					}
+					li, err := checklistIndex(lists, list)
+					if err != nil {
+						return nil, "", err
+					}
+					title := lists[li].Title
+					return append(lists[:li], lists[li+1:]...), fmt.Sprintf("Checklist %q removed", title), nil
+				}
+				// Resolve every item first: removing shifts the numbers.
+				drop := map[int]map[int]bool{}
+				for _, ref := range args[1:] {
+					li, ii, err := checklistItem(lists, list, ref)
+					if err != nil {
+						return nil, "", err
+					}
+					if drop[li] == nil {
+						drop[li] = map[int]bool{}
+					}
+					drop[li][ii] = true
+				}
+				removed := 0
+				for li, items := range drop {
+					idx := make([]int, 0, len(items))
+					for ii := range items {
+						idx = append(idx, ii)
+					}
+					sort.Sort(sort.Reverse(sort.IntSlice(idx)))
+					for _, ii := range idx {
+						lists[li].Items = append(lists[li].Items[:ii], lists[li].Items[ii+1:]...)
+						removed++
+					}
+				}
+				return lists, fmt.Sprintf("Removed %d item(s)", removed), nil
+			})
+		},
+	}
+	c.Flags().StringVar(&list, "list", "", "checklist title or number")
+	return c
+}
+
+// NewTasksChecklistCmd returns the "tasks checklist" parent command.
+func NewTasksChecklistCmd(g *Globals) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "checklist",
+		Short: "Edit a task's checklists",
+		Long: `Edit a task's checklists. Items are given as list.item numbers shown by
+"tasks checklist list" (e.g. 2.3), as a number in the checklist chosen with
+--list (or the task's only checklist), or by title.
+
+The API replaces all checklists of a task at once, so each change reads the
+task, edits the checklists and writes them back. The task is read again
+right before writing; if its checklists changed meanwhile, nothing is written.`,
+	}
+	c.AddCommand(NewTasksChecklistListCmd(g))
+	c.AddCommand(NewTasksChecklistAddCmd(g))
+	c.AddCommand(newTasksChecklistMarkCmd(g, "check", "Mark checklist items done", true))
+	c.AddCommand(newTasksChecklistMarkCmd(g, "uncheck", "Mark checklist items not done", false))
+	c.AddCommand(NewTasksChecklistRenameCmd(g))
+	c.AddCommand(NewTasksChecklistRemoveCmd(g))
+	return c
+}
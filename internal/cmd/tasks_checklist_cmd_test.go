@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const editTaskID = "0a1b2c3d-0000-4000-8000-000000000001"
+
+const releaseChecklists = `{"id":"` + editTaskID + `","title":"Release","checklists":[` +
+	`{"title":"Build","items":[{"title":"Tag","isCompleted":true},{"title":"Publish","isCompleted":false}]},` +
+	`{"title":"Docs","items":[{"title":"Publish","isCompleted":false}]}]}`
+
+// taskEditServer serves the task from reads in order (repeating the last)
+// and records the body of the update.
+func taskEditServer(t *testing.T, reads ...string) (*httptest.Server, *map[string]interface{}) {
+	t.Helper()
+	var put map[string]interface{}
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&put)
+			_, _ = io.WriteString(w, `{"id":"`+editTaskID+`"}`)
+			return
+		}
+		_, _ = io.WriteString(w, reads[min(n, len(reads)-1)])
+		n++
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &put
+}
+
+func putChecklists(t *testing.T, put map[string]interface{}) string {
+	t.Helper()
+	if put["checklists"] == nil {
+		t.Fatalf("no checklists written: %v", put)
+	}
+	b, _ := json.Marshal(put["checklists"])
+	return string(b)
+}
+
+func TestChecklistList_NumbersItems(t *testing.T) {
+	srv, _ := taskEditServer(t, releaseChecklists)
+	out := runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "list", editTaskID)
+	for _, want := range []string{"1.1", "Build", "[x]", "Tag", "2.1", "Docs"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestChecklistCheck_ByNumberAndTitle(t *testing.T) {
+	srv, put := taskEditServer(t, releaseChecklists)
+	runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "check", editTaskID, "1.2", "2.1")
+	want := `[{"items":[{"isCompleted":true,"title":"Tag"},{"isCompleted":true,"title":"Publish"}],"title":"Build"},` +
+		`{"items":[{"isCompleted":true,"title":"Publish"}],"title":"Docs"}]`
+	if got := putChecklists(t, *put); got != want {
+		t.Errorf("checklists = %s\nwant %s", got, want)
+	}
+
+	srv, put = taskEditServer(t, releaseChecklists)
+	runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "uncheck", editTaskID, "tag")
+	if got := putChecklists(t, *put); !strings.Contains(got, `{"isCompleted":false,"title":"Tag"}`) {
+		t.Errorf("checklists = %s", got)
+	}
+}
+
+func TestChecklistItem_AmbiguousTitle(t *testing.T) {
+	srv, put := taskEditServer(t, releaseChecklists)
+	c := NewTasksChecklistCmd(statusGlobals(t, srv.URL))
+	c.SetArgs([]string{"check", editTaskID, "Publish"})
+	c.SetOut(io.Discard)
+	err := c.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1.2, 2.1") {
+		t.Errorf("err = %v, want ambiguity listing 1.2, 2.1", err)
+	}
+	if *put != nil {
+		t.Error("nothing should be written")
+	}
+}
+
+func TestChecklistAdd_CreatesList(t *testing.T) {
+	srv, put := taskEditServer(t, releaseChecklists)
+	runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "add", editTaskID, "--list", "QA", "Smoke", "Regression")
+	got := putChecklists(t, *put)
+	if !strings.HasSuffix(got, `{"items":[{"isCompleted":false,"title":"Smoke"},{"isCompleted":false,"title":"Regression"}],"title":"QA"}]`) {
+		t.Errorf("checklists = %s", got)
+	}
+}
+
+func TestChecklistRemove_ItemsAndList(t *testing.T) {
+	srv, put := taskEditServer(t, releaseChecklists)
+	runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "remove", editTaskID, "1.1", "1.2")
+	if got := putChecklists(t, *put); !strings.HasPrefix(got, `[{"items":[],"title":"Build"}`) {
+		t.Errorf("checklists = %s", got)
+	}
+
+	srv, put = taskEditServer(t, releaseChecklists)
+	runCmd(t, NewTasksChecklistCmd(statusGlobals(t, srv.URL)), "remove", editTaskID, "--list", "build")
+	if got := putChecklists(t, *put); strings.Contains(got, "Build") || !strings.Contains(got, "Docs") {
+		t.Errorf("checklists = %s", got)
+	}
+}
+
+func TestChecklistEdit_AbortsWhenTaskChanged(t *testing.T) {
+	changed := strings.Replace(releaseChecklists, `"Tag","isCompleted":true`, `"Tag","isCompleted":false`, 1)
+	srv, put := taskEditServer(t, releaseChecklists, changed)
+	c := NewTasksChecklistCmd(statusGlobals(t, srv.URL))
+	c.SetArgs([]string{"rename", editTaskID, "1.2", "Publish to store"})
+	c.SetOut(io.Discard)
+	if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "changed while editing") {
+		t.Errorf("err = %v, want changed while editing", err)
+	}
+	if *put != nil {
+		t.Errorf("wrote %v despite the concurrent change", *put)
+	}
+}
@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/angolovin/yougile-cli/internal/output"
 	"github.com/angolovin/yougile-cli/pkg/client"
@@ -12,109 +13,403 @@ import (
 )
 
 // NewTasksListCmd returns the "tasks list" command.
-func NewTasksListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTasksListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
-	var title, columnID string
+	var all, includeDeleted, overdue bool
+	var title, columnID, assigned, sticker, stickerState string
+	var board, project, completedStr, archivedStr, deadlineBefore, deadlineAfter, createdBy, color string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List tasks",
+		Long: `List tasks. --title, --column, --assigned, --sticker, --sticker-state and
+--include-deleted are applied by the API. The other filters are applied to
+the fetched tasks: with any of them set, pages are scanned until --limit
+matching tasks are found (or every page with --all).`,
+		Example: `  yougile tasks list --assigned me --overdue --project Marketing
+  yougile tasks list --board "Marketing/Dev" --completed false --deadline-before 2024-06-01`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
-
-			params := &client.TaskControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
+			ctx := context.Background()
+			n := g.lookupNames(ctx, api, s)
+			res := n
+			if res == nil {
+				res = g.newNames(ctx, api, s)
 			}
+
+			params := client.TaskControllerSearchParams{}
 			if title != "" {
 				params.Title = strPtr(title)
 			}
 			if columnID != "" {
-				params.ColumnId = strPtr(columnID)
+				id, err := res.resolveColumn(columnID)
+				if err != nil {
+					return err
+				}
+				params.ColumnId = strPtr(id)
+			}
+			if assigned != "" {
+				ids, err := res.resolveUsers(assigned)
+				if err != nil {
+					return err
+				}
+				params.AssignedTo = strPtr(strings.Join(ids, ","))
+			}
+			stickerID := ""
+			if sticker != "" {
+				if stickerID, err = res.resolveSticker(sticker); err != nil {
+					return err
+				}
+				params.StickerId = strPtr(stickerID)
+			}
+			if stickerState != "" {
+				if sticker == "" && !isID(stickerState) {
+					return fmt.Errorf("--sticker-state by name needs --sticker")
+				}
+				id, err := res.resolveStickerState(stickerID, stickerState)
+				if err != nil {
+					return err
+				}
+				params.StickerStateId = strPtr(id)
+			}
+			if includeDeleted {
+				params.IncludeDeleted = boolPtr(true)
 			}
 
-			resp, err := api.TaskControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list tasks: %w", err)
+			filter := taskFilter{overdue: overdue, now: time.Now()}
+			if filter.columns, err = res.scopeColumns(board, project); err != nil {
+				return err
 			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list tasks: HTTP %s", resp.HTTPResponse.Status)
+			if cmd.Flags().Changed("completed") {
+				if filter.completed = parseOptionalBool(completedStr); filter.completed == nil {
+					return fmt.Errorf("--completed must be true or false")
+				}
 			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list tasks: empty response")
+			if cmd.Flags().Changed("archived") {
+				if filter.archived = parseOptionalBool(archivedStr); filter.archived == nil {
+					return fmt.Errorf("--archived must be true or false")
+				}
+			}
+			if deadlineBefore != "" {
+				if filter.deadlineBefore, err = parseDate(deadlineBefore); err != nil {
+					return fmt.Errorf("--deadline-before: %w", err)
+				}
+			}
+			if deadlineAfter != "" {
+				if filter.deadlineAfter, err = parseDate(deadlineAfter); err != nil {
+					return fmt.Errorf("--deadline-after: %w", err)
+				}
+			}
+			if createdBy != "" {
+				if filter.createdBy, err = res.resolveUser(createdBy); err != nil {
+					return err
+				}
+			}
+			if color != "" {
+				if filter.color, err = parseTaskColor(color); err != nil {
+					return err
+				}
 			}
 
+			fetch := taskSearch(api, params)
+			result := func(tasks []client.TaskListDtoBase, paging client.PagingMetadata) output.Result {
+				table := &output.Table{Columns: []output.Column{
+					{Header: "ID"}, {Header: "Title"}, {Header: "Column", Field: "columnId"}, {Header: "Assigned"}, {Header: "Deadline"},
+					{Header: "Completed", Wide: true}, {Header: "Stickers", Wide: true}, {Header: "Created", Wide: true, Field: "timestamp"},
+				}}
+				for _, t := range tasks {
+					column, assigned, deadline := "", "", ""
+					if t.ColumnId != nil {
+						column = n.columnTitle(*t.ColumnId)
+					}
+					if t.Assigned != nil {
+						assigned = strings.Join(n.userList(*t.Assigned), ", ")
+					}
+					if t.Deadline != nil {
+						deadline = formatTimestamp(t.Deadline.Deadline)
+					}
+					table.Rows = append(table.Rows, []string{t.Id, t.Title, column, assigned, deadline, yesNo(t.Completed), stickerList(n, t.Stickers), formatTimestamp(int64(t.Timestamp))})
+				}
+				return output.Result{Value: client.TaskListDto{Content: tasks, Paging: paging}, Items: tasks, Table: table}
+			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
+			if !filter.active() {
+				return listPages(ctx, g, out, limit, offset, all, fetch, result)
 			}
-			headers := []string{"ID", "Title", "ColumnId"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, t := range resp.JSON200.Content {
-				colID := ""
-				if t.ColumnId != nil {
-					colID = *t.ColumnId
-				}
-				rows = append(rows, []string{t.Id, t.Title, colID})
+			if all && g.streamsPages() {
+				return streamPages(ctx, g, out, lookupPageSize, offset, fetch, filter.match, result)
+			}
+			tasks, paging, err := collectMatches(ctx, lookupPageSize, limit, offset, all, fetch, filter.match)
+			if err != nil {
+				return err
 			}
-			return output.PrintTable(out, headers, rows)
+			return g.print(out, result(tasks, paging))
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&title, "title", "", "filter by title")
-	c.Flags().StringVar(&columnID, "column-id", "", "filter by column ID")
+	refFlag(c, &columnID, "column", `filter by column: ID, "Column", "Board/Column" or "Project/Board/Column"`)
+	c.Flags().StringVar(&assigned, "assigned", "", `filter by assignees: comma-separated IDs, emails, names or "me"`)
+	c.Flags().StringVar(&sticker, "sticker", "", "filter by sticker (ID or name)")
+	c.Flags().StringVar(&stickerState, "sticker-state", "", "filter by sticker state (ID, or name with --sticker)")
+	c.Flags().BoolVar(&includeDeleted, "include-deleted", false, "include deleted tasks")
+	c.Flags().StringVar(&board, "board", "", `only tasks on this board: ID, "Board" or "Project/Board"`)
+	c.Flags().StringVar(&project, "project", "", "only tasks in this project (ID or name)")
+	c.Flags().StringVar(&completedStr, "completed", "", "only completed (true) or open (false) tasks")
+	c.Flags().StringVar(&archivedStr, "archived", "", "only archived (true) or active (false) tasks")
+	c.Flags().StringVar(&deadlineBefore, "deadline-before", "", "only tasks due before this date, e.g. 2024-06-01, today or +7d")
+	c.Flags().StringVar(&deadlineAfter, "deadline-after", "", "only tasks due on or after this date")
+	c.Flags().BoolVar(&overdue, "overdue", false, "only open tasks past their deadline")
+	c.Flags().StringVar(&createdBy, "created-by", "", `only tasks created by this user (ID, email, name or "me")`)
+	c.Flags().StringVar(&color, "color", "", "only tasks of this card color, e.g. red or task-red")
 	return c
 }
 
+// taskSearch returns a page fetcher for the task list with params.
+func taskSearch(api *client.ClientWithResponses, params client.TaskControllerSearchParams) pageFetcher[client.TaskListDtoBase] {
+	return func(ctx context.Context, limit, offset int) ([]client.TaskListDtoBase, client.PagingMetadata, error) {
+		p := params
+		p.Limit, p.Offset = pageParams(limit, offset)
+		resp, err := api.TaskControllerSearchWithResponse(ctx, &p)
+		if err != nil {
+			return nil, client.PagingMetadata{}, fmt.Errorf("list tasks: %w", err)
+		}
+		if resp.HTTPResponse.StatusCode != 200 {
+			return nil, client.PagingMetadata{}, apiError("list tasks", resp.HTTPResponse, resp.Body)
+		}
+		if resp.JSON200 == nil {
+			return nil, client.PagingMetadata{}, fmt.Errorf("list tasks: empty response")
+		}
+		return resp.JSON200.Content, resp.JSON200.Paging, nil
+	}
+}
+
 // NewTasksCreateCmd returns the "tasks create" command.
-func NewTasksCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
-	var title, columnID string
+func NewTasksCreateCmd(g *Globals) *cobra.Command {
+	var title, columnID, description, color, assigned, completedStr, archivedStr string
+	var dates deadlineFlags
+	var planStr, workStr, subtasks, parent, timer string
+	var dealOrganization, dealContacts string
+	var dealAmount float32
+	var checklists, stickers, dealFields []string
+	var stopwatch bool
 	c := &cobra.Command{
 		Use:   "create",
 		Short: "Create a task",
+		Long: `Create a task with all its fields in one request.
+
+Users, columns, stickers, sticker states and subtasks may be given by ID or
+name (subtasks also by key such as ID-123). With --parent the new task is
+also appended to that task's subtasks.`,
+		Example: `  yougile tasks create --title "Release notes" --column "Dev/Todo" --assigned me,alice@corp.com \
+    --color red --deadline "next friday 18:00" --time-plan 4 --sticker "Priority=High" \
+    --checklist "Steps: draft; review; [x] outline" --subtasks ID-120,ID-121`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if title == "" {
 				return fmt.Errorf("title is required (--title)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
+			n := g.newNames(context.Background(), api, s)
 			body := client.TaskControllerCreateJSONRequestBody{Title: title}
 			if columnID != "" {
-				body.ColumnId = &columnID
+				id, err := n.resolveColumn(columnID)
+				if err != nil {
+					return err
+				}
+				body.ColumnId = &id
+			}
+			if description != "" {
+				body.Description = &description
+			}
+			if color != "" {
+				col, err := parseTaskColor(color)
+				if err != nil {
+					return err
+				}
+				body.Color = &col
 			}
+			if assigned != "" {
+				ids, err := n.resolveUsers(assigned)
+				if err != nil {
+					return err
+				}
+				body.Assigned = &ids
+			}
+			if cmd.Flags().Changed("completed") {
+				if body.Completed = parseOptionalBool(completedStr); body.Completed == nil {
+					return fmt.Errorf("--completed must be true or false")
+				}
+			}
+			if cmd.Flags().Changed("archived") {
+				if body.Archived = parseOptionalBool(archivedStr); body.Archived == nil {
+					return fmt.Errorf("--archived must be true or false")
+				}
+			}
+			if body.Deadline, err = dates.createDeadline(); err != nil {
+				return err
+			}
+			for _, v := range checklists {
+				cl, err := parseChecklist(v)
+				if err != nil {
+					return err
+				}
+				if body.Checklists == nil {
+					body.Checklists = &[]client.CheckList{}
+				}
+				*body.Checklists = append(*body.Checklists, cl)
+			}
+			if len(stickers) > 0 {
+				m, err := parseStickers(n, stickers)
+				if err != nil {
+					return err
+				}
+				body.Stickers = &m
+			}
+			if planStr != "" || workStr != "" {
+				tt := client.TimeTracking{}
+				if planStr != "" {
+					if tt.Plan, err = parseHours(planStr); err != nil {
+						return fmt.Errorf("--time-plan: %w", err)
+					}
+				}
+				if workStr != "" {
+					if tt.Work, err = parseHours(workStr); err != nil {
+						return fmt.Errorf("--time-work: %w", err)
+					}
+				}
+				body.TimeTracking = &tt
+			}
+			var parentID string
+			if parent != "" {
+				if parentID, err = n.resolveTask(parent); err != nil {
+					return err
+				}
+			}
+			if subtasks != "" {
+				ids, err := n.resolveTasks(subtasks)
+				if err != nil {
+					return err
+				}
+				body.Subtasks = &ids
+			}
+			if stopwatch {
+				body.Stopwatch = &client.CreateStopwatch{Running: true}
+			}
+			if timer != "" {
+				d, err := time.ParseDuration(timer)
+				if err != nil || d <= 0 {
+					return fmt.Errorf("--timer: invalid duration %q (want e.g. 25m or 1h30m)", timer)
+				}
+				body.Timer = &client.CreateTimer{Running: true, Seconds: float32(d.Seconds())}
+			}
+			if cmd.Flags().Changed("deal-amount") || dealOrganization != "" || dealContacts != "" || len(dealFields) > 0 {
+				deal := client.DealDataDto{}
+				if cmd.Flags().Changed("deal-amount") {
+					deal.DealAmount = &dealAmount
+				}
+				if dealOrganization != "" {
+					deal.OrganizationId = &dealOrganization
+				}
+				if dealContacts != "" {
+					ids := strings.Split(dealContacts, ",")
+					for i := range ids {
+						ids[i] = strings.TrimSpace(ids[i])
+					}
+					deal.ContactPersonIds = &ids
+				}
+				if len(dealFields) > 0 {
+					m, err := parseKeyValues("deal-field", dealFields)
+					if err != nil {
+						return err
+					}
+					deal.CustomFields = &m
+				}
+				body.Deal = &deal
+			}
+
 			resp, err := api.TaskControllerCreateWithResponse(context.Background(), body)
 			if err != nil {
 				return fmt.Errorf("create task: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create task: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create task", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Task created: id=%s\n", resp.JSON201.Id)
-				return err
+			msg := fmt.Sprintf("Task created: id=%s", resp.JSON201.Id)
+			if parentID != "" {
+				if _, err := addSubtasks(context.Background(), api, parentID, []string{resp.JSON201.Id}); err != nil {
+					return fmt.Errorf("task %s created, but adding it to the subtasks of %s failed: %w", resp.JSON201.Id, parent, err)
+				}
+				msg += ", subtask of " + parent
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: msg})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "task title")
-	c.Flags().StringVar(&columnID, "column-id", "", "column ID (optional)")
+	refFlag(c, &columnID, "column", "column ID or name (optional)")
+	c.Flags().StringVar(&description, "description", "", "task description")
+	c.Flags().StringVar(&color, "color", "", taskColorUsage)
+	c.Flags().StringVar(&assigned, "assigned", "", `comma-separated users to assign: IDs, emails, names or "me"`)
+	c.Flags().StringVar(&completedStr, "completed", "", "mark completed: true or false")
+	c.Flags().StringVar(&archivedStr, "archived", "", "archive task: true or false")
+	dates.register(c, false)
+	c.Flags().StringArrayVar(&checklists, "checklist", nil, `checklist "Title: item; [x] done item" (repeatable)`)
+	c.Flags().StringArrayVar(&stickers, "sticker", nil, "sticker value Sticker=State, or Sticker=text for free fields (repeatable)")
+	c.Flags().StringVar(&planStr, "time-plan", "", "planned time in hours, e.g. 4 or 2h30m")
+	c.Flags().StringVar(&workStr, "time-work", "", "time already worked in hours")
+	c.Flags().StringVar(&subtasks, "subtasks", "", "comma-separated subtasks: task IDs or keys")
+	c.Flags().StringVar(&parent, "parent", "", "add the new task to this task's subtasks (ID or key)")
+	c.Flags().BoolVar(&stopwatch, "stopwatch", false, "start a stopwatch on the task")
+	c.Flags().StringVar(&timer, "timer", "", "start a timer, e.g. 25m")
+	c.Flags().Float32Var(&dealAmount, "deal-amount", 0, "create the task as a CRM deal with this amount")
+	c.Flags().StringVar(&dealOrganization, "deal-organization-id", "", "CRM deal organization ID")
+	c.Flags().StringVar(&dealContacts, "deal-contact-ids", "", "comma-separated CRM deal contact person IDs")
+	c.Flags().StringArrayVar(&dealFields, "deal-field", nil, "CRM deal custom field fieldId=value (repeatable)")
 	_ = c.MarkFlagRequired("title")
 	return c
 }
 
+// stickerList formats task stickers as "Name: State" pairs sorted by name.
+func stickerList(n *names, stickers *map[string]interface{}) string {
+	if stickers == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*stickers))
+	for id, value := range *stickers {
+		name, state := n.sticker(id, value)
+		pairs = append(pairs, name+": "+state)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// formatTimestamp formats a YouGile timestamp (ms since epoch) in local time; 0 is "".
+func formatTimestamp(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Local().Format("2006-01-02 15:04")
+}
+
+// yesNo renders an optional flag as "yes"/"no".
+func yesNo(b *bool) string {
+	if b != nil && *b {
+		return "yes"
+	}
+	return "no"
+}
+
 // parseOptionalBool parses "true"/"false" into *bool. Returns nil for invalid or empty.
 func parseOptionalBool(s string) *bool {
 	switch strings.TrimSpace(strings.ToLower(s)) {
@@ -128,37 +423,53 @@ func parseOptionalBool(s string) *bool {
 }
 
 // NewTasksUpdateCmd returns the "tasks update" command.
-func NewTasksUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTasksUpdateCmd(g *Globals) *cobra.Command {
 	var title, columnID, description, color, assigned, completedStr, archivedStr, deletedStr string
+	var dates deadlineFlags
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a task",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			n := g.newNames(context.Background(), api, s)
+			id, err := n.resolveTask(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			body := client.TaskControllerUpdateJSONRequestBody{}
 			if cmd.Flags().Changed("title") {
 				body.Title = &title
 			}
-			if cmd.Flags().Changed("column-id") {
+			if refChanged(cmd, "column") {
+				columnID, err := n.resolveColumn(columnID)
+				if err != nil {
+					return err
+				}
 				body.ColumnId = &columnID
 			}
 			if cmd.Flags().Changed("description") {
 				body.Description = &description
 			}
 			if cmd.Flags().Changed("color") {
-				body.Color = &color
+				col, err := parseTaskColor(color)
+				if err != nil {
+					return err
+				}
+				body.Color = &col
 			}
 			if cmd.Flags().Changed("assigned") {
-				parts := strings.Split(assigned, ",")
-				for i := range parts {
-					parts[i] = strings.TrimSpace(parts[i])
+				ids, err := n.resolveUsers(assigned)
+				if err != nil {
+					return err
 				}
-				body.Assigned = &parts
+				if ids == nil {
+					ids = []string{}
+				}
+				body.Assigned = &ids
 			}
 			if cmd.Flags().Changed("completed") {
 				if b := parseOptionalBool(completedStr); b != nil {
@@ -181,69 +492,77 @@ func NewTasksUpdateCmd(resolvePath func() (string, error), outputJSON func() boo
 					return fmt.Errorf("--deleted must be true or false")
 				}
 			}
+			if dates.deadline != "" || dates.start != "" {
+				cur, err := getTask(context.Background(), api, id)
+				if err != nil {
+					return err
+				}
+				if body.Deadline, err = dates.updateDeadline(cur.Deadline); err != nil {
+					return err
+				}
+			} else if dates.clear {
+				body.Deadline, _ = dates.updateDeadline(nil)
+			}
 			resp, err := api.TaskControllerUpdateWithResponse(context.Background(), id, body)
 			if err != nil {
 				return fmt.Errorf("update task: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update task: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update task", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "Task updated: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Task updated: id=%s", id)})
 		},
 	}
 	c.Flags().StringVar(&title, "title", "", "task title")
-	c.Flags().StringVar(&columnID, "column-id", "", "column ID (move task to another column)")
+	refFlag(c, &columnID, "column", "move task to another column (ID or name)")
 	c.Flags().StringVar(&description, "description", "", "task description")
-	c.Flags().StringVar(&color, "color", "", "card color: task-primary, task-gray, task-red, task-pink, task-yellow, task-green, task-turquoise, task-blue, task-violet")
-	c.Flags().StringVar(&assigned, "assigned", "", "comma-separated user IDs to assign")
+	c.Flags().StringVar(&color, "color", "", taskColorUsage)
+	c.Flags().StringVar(&assigned, "assigned", "", `comma-separated users to assign: IDs, emails, names or "me" ("" unassigns all)`)
 	c.Flags().StringVar(&completedStr, "completed", "", "mark completed: true or false")
 	c.Flags().StringVar(&archivedStr, "archived", "", "archive task: true or false")
 	c.Flags().StringVar(&deletedStr, "deleted", "", "soft delete: true or false")
+	dates.register(c, true)
 	return c
 }
 
 // NewTasksChatSubscribersGetCmd returns the "tasks chat-subscribers get" command.
-func NewTasksChatSubscribersGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTasksChatSubscribersGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [task-id]",
 		Short: "Get task chat subscribers",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveTask(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			resp, err := api.TaskControllerGetChatSubscribersWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("get chat subscribers: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get chat subscribers: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get chat subscribers", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
+			if resp.JSON200 == nil {
+				return nil
 			}
-			if resp.JSON200 != nil {
-				for _, uid := range *resp.JSON200 {
-					if _, err := fmt.Fprintln(out, uid); err != nil {
-						return err
-					}
-				}
+			rows := make([][]string, 0, len(*resp.JSON200))
+			for _, uid := range *resp.JSON200 {
+				rows = append(rows, []string{uid})
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON200, Items: resp.JSON200, Table: output.NewTable([]string{"UserId"}, rows)})
 		},
 	}
 }
 
 // NewTasksChatSubscribersUpdateCmd returns the "tasks chat-subscribers update" command.
-func NewTasksChatSubscribersUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTasksChatSubscribersUpdateCmd(g *Globals) *cobra.Command {
 	var userIDs string
 	c := &cobra.Command{
 		Use:   "update [task-id]",
@@ -253,81 +572,95 @@ func NewTasksChatSubscribersUpdateCmd(resolvePath func() (string, error), output
 			if userIDs == "" {
 				return fmt.Errorf("user-ids is required (--user-ids id1,id2,...)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			n := g.newNames(context.Background(), api, s)
+			id, err := n.resolveTask(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
-			parts := strings.Split(userIDs, ",")
-			for i := range parts {
-				parts[i] = strings.TrimSpace(parts[i])
+			ids, err := n.resolveUsers(userIDs)
+			if err != nil {
+				return err
 			}
-			body := client.TaskControllerUpdateChatSubscribersJSONRequestBody{Content: &parts}
+			body := client.TaskControllerUpdateChatSubscribersJSONRequestBody{Content: &ids}
 			resp, err := api.TaskControllerUpdateChatSubscribersWithResponse(context.Background(), id, body)
 			if err != nil {
 				return fmt.Errorf("update chat subscribers: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update chat subscribers: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update chat subscribers", resp.HTTPResponse, resp.Body)
 			}
-			out := cmd.OutOrStdout()
-			_, err = fmt.Fprintf(out, "Chat subscribers updated for task %s\n", id)
-			return err
+			return g.print(cmd.OutOrStdout(), output.Result{Value: resp.JSON200, Message: fmt.Sprintf("Chat subscribers updated for task %s", id)})
 		},
 	}
-	c.Flags().StringVar(&userIDs, "user-ids", "", "comma-separated list of user IDs")
+	c.Flags().StringVar(&userIDs, "user-ids", "", "comma-separated users: IDs, emails, names or \"me\"")
 	_ = c.MarkFlagRequired("user-ids")
 	return c
 }
 
 // NewTaskGetCmd returns the "tasks get" command.
-func NewTaskGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTaskGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
-		Short: "Get task by ID",
-		Args:  cobra.ExactArgs(1),
+		Short: "Show a task",
+		Long: `Show a task as a card: path, assignees, dates, time tracking, stickers,
+checklists, subtasks and description, with IDs resolved to names.
+The task is an ID or a key such as ID-123.
+Use -o json or -o yaml for the raw API object.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 
-			resp, err := api.TaskControllerGetWithResponse(context.Background(), id)
+			resp, err := api.TaskControllerGetWithResponse(ctx, id)
 			if err != nil {
 				return fmt.Errorf("get task: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get task: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get task", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get task: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
+			r := output.Result{Value: resp.JSON200}
+			// Only the card needs names; skip the lookups for machine formats.
+			if f := g.format(); f == output.FormatTable || f == output.FormatWide {
+				r.Message = buildTaskCard(ctx, api, g.lookupNames(ctx, api, s), resp.JSON200).String()
 			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, r)
 		},
 	}
 }
 
 // NewTasksCmd returns the "tasks" parent command.
-func NewTasksCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewTasksCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "tasks",
 		Short: "Manage tasks",
 	}
-	c.AddCommand(NewTasksListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewTaskGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewTasksCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewTasksUpdateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewTasksListCmd(g))
+	c.AddCommand(NewTaskGetCmd(g))
+	c.AddCommand(NewTasksCreateCmd(g))
+	c.AddCommand(NewTasksUpdateCmd(g))
+	c.AddCommand(NewTasksChecklistCmd(g))
+	c.AddCommand(NewTasksSubtasksCmd(g))
+	c.AddCommand(NewTasksTreeCmd(g))
+	c.AddCommand(NewTasksTimeCmd(g))
 	chatSubs := &cobra.Command{Use: "chat-subscribers", Short: "Task chat subscribers"}
-	chatSubs.AddCommand(NewTasksChatSubscribersGetCmd(resolvePath, outputJSON))
-	chatSubs.AddCommand(NewTasksChatSubscribersUpdateCmd(resolvePath, outputJSON))
+	chatSubs.AddCommand(NewTasksChatSubscribersGetCmd(g))
+	chatSubs.AddCommand(NewTasksChatSubscribersUpdateCmd(g))
 	c.AddCommand(chatSubs)
 	return c
 }
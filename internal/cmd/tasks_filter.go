@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+// taskFilter holds the "tasks list" filters the search endpoint lacks; they
+// are applied to each fetched page.
+type taskFilter struct {
+	columns        map[string]bool // --board/--project: columns of the board or project
+	completed      *bool
+	archived       *bool
+	deadlineBefore time.Time
+	deadlineAfter  time.Time
+	overdue        bool
+	createdBy      string
+	color          string
+	now            time.Time
+}
+
+// active reports whether any client-side filter is set.
+func (f *taskFilter) active() bool {
+	return f.columns != nil || f.completed != nil || f.archived != nil ||
+		!f.deadlineBefore.IsZero() || !f.deadlineAfter.IsZero() || f.overdue ||
+		f.createdBy != "" || f.color != ""
+}
+
+// match reports whether t passes every filter.
+func (f *taskFilter) match(t client.TaskListDtoBase) bool {
+	if f.columns != nil && (t.ColumnId == nil || !f.columns[*t.ColumnId]) {
+		return false
+	}
+	if f.completed != nil && isSet(t.Completed) != *f.completed {
+		return false
+	}
+	if f.archived != nil && isSet(t.Archived) != *f.archived {
+		return false
+	}
+	if f.createdBy != "" && (t.CreatedBy == nil || *t.CreatedBy != f.createdBy) {
+		return false
+	}
+	if f.color != "" && (t.Color == nil || *t.Color != f.color) {
+		return false
+	}
+	if f.deadlineBefore.IsZero() && f.deadlineAfter.IsZero() && !f.overdue {
+		return true
+	}
+	if t.Deadline == nil || t.Deadline.Deadline == 0 {
+		return false
+	}
+	deadline := time.UnixMilli(t.Deadline.Deadline)
+	if !f.deadlineBefore.IsZero() && !deadline.Before(f.deadlineBefore) {
+		return false
+	}
+	if !f.deadlineAfter.IsZero() && deadline.Before(f.deadlineAfter) {
+		return false
+	}
+	if f.overdue {
+		if isSet(t.Completed) {
+			return false
+		}
+		// A deadline without a time is due at the end of its day, so it is
+		// overdue once it is before the start of today (in the config zone,
+		// which is time.Local).
+		due := f.now
+		if t.Deadline.WithTime != nil && !*t.Deadline.WithTime {
+			due = startOfDay(f.now)
+		}
+		if !deadline.Before(due) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
+
+// boardColumns returns the IDs of the columns on board.
+func (n *names) boardColumns(board string) (map[string]bool, error) {
+	if err := n.loadColumns(); err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, c := range n.columns {
+		if c.BoardId == board {
+			ids[c.Id] = true
+		}
+	}
+	return ids, nil
+}
+
+// projectColumns returns the IDs of the columns on the project's boards.
+func (n *names) projectColumns(project string) (map[string]bool, error) {
+	if err := errors.Join(n.loadColumns(), n.loadBoards()); err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, c := range n.columns {
+		if b, ok := n.boardDto(c.BoardId); ok && b.ProjectId == project {
+			ids[c.Id] = true
+		}
+	}
+	return ids, nil
+}
+
+// scopeColumns returns the columns of board and/or project (nil when neither
+// is given), for the --board and --project filters.
+func (n *names) scopeColumns(board, project string) (map[string]bool, error) {
+	var cols map[string]bool
+	if board != "" {
+		id, err := n.resolveBoard(board)
+		if err != nil {
+			return nil, err
+		}
+		if cols, err = n.boardColumns(id); err != nil {
+			return nil, err
+		}
+	}
+	if project != "" {
+		id, err := n.resolveProject(project)
+		if err != nil {
+			return nil, err
+		}
+		inProject, err := n.projectColumns(id)
+		if err != nil {
+			return nil, err
+		}
+		if cols == nil {
+			return inProject, nil
+		}
+		for col := range cols {
+			if !inProject[col] {
+				delete(cols, col)
+			}
+		}
+	}
+	return cols, nil
+}
+
+// taskColors are the card colors a task can have.
+var taskColors = []string{"task-primary", "task-gray", "task-red", "task-pink", "task-yellow", "task-green", "task-turquoise", "task-blue", "task-violet"}
+
+// taskColorUsage is the help of the --color flag of tasks create and update.
+const taskColorUsage = "card color: primary, gray, red, pink, yellow, green, turquoise, blue, violet"
+
+// parseTaskColor accepts a card color with or without the "task-" prefix.
+func parseTaskColor(s string) (string, error) {
+	c := strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(c, "task-") {
+		c = "task-" + c
+	}
+	for _, known := range taskColors {
+		if c == known {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("unknown color %q (want one of %s)", s, strings.Join(taskColors, ", "))
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/angolovin/yougile-cli/pkg/client"
+)
+
+func TestTaskFilter_Overdue(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	ms := func(t time.Time) int64 { return t.UnixMilli() }
+	task := func(deadline time.Time, withTime, completed bool) client.TaskListDtoBase {
+		return client.TaskListDtoBase{
+			Deadline:  &client.Deadline{Deadline: ms(deadline), WithTime: &withTime},
+			Completed: &completed,
+		}
+	}
+	f := taskFilter{overdue: true, now: now}
+	for name, tc := range map[string]struct {
+		task client.TaskListDtoBase
+		want bool
+	}{
+		"past":               {task(now.Add(-time.Hour), true, false), true},
+		"future":             {task(now.Add(time.Hour), true, false), false},
+		"completed":          {task(now.Add(-time.Hour), true, true), false},
+		"date due today":     {task(startOfDay(now), false, false), false},
+		"date due yesterday": {task(startOfDay(now).AddDate(0, 0, -1), false, false), true},
+		"1ms before today":   {task(startOfDay(now).Add(-time.Millisecond), false, false), true},
+		"no deadline":        {client.TaskListDtoBase{}, false},
+	} {
+		if got := f.match(tc.task); got != tc.want {
+			t.Errorf("%s: match = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestTasksListCmd_ClientFilters(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := map[string]string{
+			"/api-v2/projects": `{"content":[{"id":"p1","title":"Marketing"},{"id":"p2","title":"Dev"}],"paging":{}}`,
+			"/api-v2/boards":   `{"content":[{"id":"b1","title":"Main","projectId":"p1"},{"id":"b2","title":"Main","projectId":"p2"}],"paging":{}}`,
+			"/api-v2/columns":  `{"content":[{"id":"c1","title":"Todo","boardId":"b1"},{"id":"c2","title":"Todo","boardId":"b2"}],"paging":{}}`,
+			"/api-v2/users":    `{"content":[{"id":"u1","email":"me@acme.io"}],"paging":{}}`,
+			"/api-v2/task-list": `{"content":[
+				{"id":"t1","title":"Late","columnId":"c1","color":"task-red","deadline":{"deadline":1000,"withTime":true}},
+				{"id":"t2","title":"Other project","columnId":"c2","color":"task-red","deadline":{"deadline":1000,"withTime":true}},
+				{"id":"t3","title":"Done","columnId":"c1","color":"task-red","completed":true,"deadline":{"deadline":1000,"withTime":true}},
+				{"id":"t4","title":"Blue","columnId":"c1","color":"task-blue","deadline":{"deadline":1000,"withTime":true}}
+			],"paging":{"next":false}}`,
+		}[r.URL.Path]
+		if r.URL.Path == "/api-v2/task-list" {
+			query = r.URL.RawQuery
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewTasksListCmd(statusGlobals(t, srv.URL))
+	out := runCmd(t, c, "--assigned", "me", "--overdue", "--project", "Marketing", "--color", "red")
+	if !strings.Contains(query, "assignedTo=u1") {
+		t.Errorf("query = %q, want assignedTo=u1", query)
+	}
+	if !strings.Contains(out, "Late") {
+		t.Errorf("want t1 in output:\n%s", out)
+	}
+	for _, unwanted := range []string{"Other project", "Done", "Blue"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("%q should be filtered out:\n%s", unwanted, out)
+		}
+	}
+}
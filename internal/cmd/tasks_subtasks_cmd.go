@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// editSubtasks changes the subtask IDs of task id with a guarded
+// read-modify-write (see editTaskField).
+func editSubtasks(ctx context.Context, api *client.ClientWithResponses, id string, edit func([]string) ([]string, error)) ([]string, error) {
+	return editTaskField(ctx, api, id, "subtasks",
+		func(t *client.TaskDto) []string {
+			if t.Subtasks == nil {
+				return []string{}
+			}
+			return *t.Subtasks
+		},
+		edit,
+		func(body *client.UpdateTaskDto, ids []string) { body.Subtasks = &ids })
+}
+
+// addSubtasks appends ids to the subtasks of parent, skipping those already there.
+func addSubtasks(ctx context.Context, api *client.ClientWithResponses, parent string, ids []string) ([]string, error) {
+	for _, id := range ids {
+		if id == parent {
+			return nil, fmt.Errorf("a task cannot be its own subtask")
+		}
+	}
+	return editSubtasks(ctx, api, parent, func(cur []string) ([]string, error) {
+		have := map[string]bool{}
+		for _, id := range cur {
+			have[id] = true
+		}
+		for _, id := range ids {
+			if !have[id] {
+				cur = append(cur, id)
+				have[id] = true
+			}
+		}
+		return cur, nil
+	})
+}
+
+// taskNode is a task in "tasks subtasks list" and "tasks tree".
+type taskNode struct {
+	ID        string     `json:"id"`
+	Key       string     `json:"key,omitempty"`
+	Title     string     `json:"title"`
+	Completed bool       `json:"completed"`
+	Subtasks  []taskNode `json:"subtasks,omitempty"`
+	// Cycle marks a task already shown higher up the tree; its subtasks
+	// are not repeated.
+	Cycle bool `json:"cycle,omitempty"`
+	// Missing marks a subtask ID that could not be fetched.
+	Missing bool `json:"missing,omitempty"`
+}
+
+func newTaskNode(t *client.TaskDto) taskNode {
+	node := taskNode{ID: t.Id, Title: t.Title, Completed: isSet(t.Completed)}
+	if t.IdTaskCommon != nil {
+		node.Key = *t.IdTaskCommon
+	}
+	return node
+}
+
+// fetchTaskNode fetches task id for a tree; a task that cannot be read is
+// marked missing rather than failing the whole tree.
+func fetchTaskNode(ctx context.Context, api *client.ClientWithResponses, id string) (taskNode, *client.TaskDto) {
+	resp, err := api.TaskControllerGetWithResponse(ctx, id)
+	if err != nil || resp.HTTPResponse.StatusCode != http.StatusOK || resp.JSON200 == nil {
+		return taskNode{ID: id, Title: id, Missing: true}, nil
+	}
+	return newTaskNode(resp.JSON200), resp.JSON200
+}
+
+// subtaskTree fetches the subtasks of t down to depth levels (0 means no
+// limit). path holds the tasks above, to stop at cycles.
+func subtaskTree(ctx context.Context, api *client.ClientWithResponses, t *client.TaskDto, depth int, path map[string]bool) []taskNode {
+	if t.Subtasks == nil || len(*t.Subtasks) == 0 {
+		return nil
+	}
+	path[t.Id] = true
+	defer delete(path, t.Id)
+	nodes := make([]taskNode, 0, len(*t.Subtasks))
+	for _, id := range *t.Subtasks {
+		if path[id] {
+			nodes = append(nodes, taskNode{ID: id, Title: id, Cycle: true})
+			continue
+		}
+		node, sub := fetchTaskNode(ctx, api, id)
+		if sub != nil && depth != 1 {
+			node.Subtasks = subtaskTree(ctx, api, sub, depth-1, path)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// label is the tree line of a task: checkbox, title and key.
+func (t taskNode) label() string {
+	switch {
+	case t.Cycle:
+		return "↺ " + t.ID + " (already shown above)"
+	case t.Missing:
+		return "? " + t.ID + " (not found)"
+	}
+	s := checkbox(t.Completed) + " " + t.Title
+	if t.Key != "" {
+		s += " (" + t.Key + ")"
+	}
+	return s
+}
+
+// writeTree draws nodes below a line with box-drawing branches.
+func writeTree(b *strings.Builder, nodes []taskNode, indent string) {
+	for i, node := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+		b.WriteString(indent + branch + node.label() + "\n")
+		writeTree(b, node.Subtasks, indent+next)
+	}
+}
+
+// countTree returns the number of completed and all tasks in nodes.
+func countTree(nodes []taskNode) (done, total int) {
+	for _, node := range nodes {
+		if node.Cycle || node.Missing {
+			continue
+		}
+		total++
+		if node.Completed {
+			done++
+		}
+		d, t := countTree(node.Subtasks)
+		done, total = done+d, total+t
+	}
+	return done, total
+}
+
+// NewTasksSubtasksListCmd returns the "tasks subtasks list" command.
+func NewTasksSubtasksListCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <task>",
+		Short: "List a task's direct subtasks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			task, err := getTask(ctx, api, id)
+			if err != nil {
+				return err
+			}
+			nodes := subtaskTree(ctx, api, task, 1, map[string]bool{})
+			if nodes == nil {
+				nodes = []taskNode{}
+			}
+			rows := make([][]string, 0, len(nodes))
+			for _, node := range nodes {
+				rows = append(rows, []string{node.ID, node.Key, node.Title, fmt.Sprint(node.Completed)})
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: nodes, Items: nodes, Table: output.NewTable([]string{"ID", "Key", "Title", "Completed"}, rows)})
+		},
+	}
+}
+
+// NewTasksSubtasksAddCmd returns the "tasks subtasks add" command.
+func NewTasksSubtasksAddCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:     "add <task> <subtask>...",
+		Short:   "Make tasks subtasks of a task",
+		Example: `  yougile tasks subtasks add ID-100 ID-101 ID-102`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			n := g.newNames(ctx, api, s)
+			parent, err := n.resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			ids, err := n.resolveTasks(strings.Join(args[1:], ","))
+			if err != nil {
+				return err
+			}
+			subtasks, err := addSubtasks(ctx, api, parent, ids)
+			if err != nil {
+				return err
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: subtasks, Message: fmt.Sprintf("Task %s has %d subtask(s)", args[0], len(subtasks))})
+		},
+	}
+}
+
+// NewTasksSubtasksRemoveCmd returns the "tasks subtasks remove" command.
+func NewTasksSubtasksRemoveCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <task> <subtask>...",
+		Short: "Detach subtasks from a task (the subtasks themselves are kept)",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			n := g.newNames(ctx, api, s)
+			parent, err := n.resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			ids, err := n.resolveTasks(strings.Join(args[1:], ","))
+			if err != nil {
+				return err
+			}
+			subtasks, err := editSubtasks(ctx, api, parent, func(cur []string) ([]string, error) {
+				drop := map[string]bool{}
+				for _, id := range ids {
+					drop[id] = true
+				}
+				kept := make([]string, 0, len(cur))
+				for _, id := range cur {
+					if drop[id] {
+						delete(drop, id)
+						continue
+					}
+					kept = append(kept, id)
+				}
+				for id := range drop {
+					return nil, fmt.Errorf("task %s is not a subtask of %s", id, args[0])
+				}
+				return kept, nil
+			})
+			if err != nil {
+				return err
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: subtasks, Message: fmt.Sprintf("Task %s has %d subtask(s)", args[0], len(subtasks))})
+		},
+	}
+}
+
+// NewTasksSubtasksCmd returns the "tasks subtasks" parent command.
+func NewTasksSubtasksCmd(g *Globals) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "subtasks",
+		Short: "Manage a task's subtasks",
+		Long: `Manage a task's subtasks. Tasks are given by ID or key such as ID-123.
+Like checklists, the subtask list is replaced as a whole: it is read again
+right before writing and nothing is written if it changed meanwhile.`,
+	}
+	c.AddCommand(NewTasksSubtasksListCmd(g))
+	c.AddCommand(NewTasksSubtasksAddCmd(g))
+	c.AddCommand(NewTasksSubtasksRemoveCmd(g))
+	return c
+}
+
+// NewTasksTreeCmd returns the "tasks tree" command.
+func NewTasksTreeCmd(g *Globals) *cobra.Command {
+	var depth int
+	c := &cobra.Command{
+		Use:   "tree <task>",
+		Short: "Show a task's subtasks recursively with their completion state",
+		Long: `Show a task's subtasks recursively with their completion state. Each
+subtask is one request, so large trees use up the rate limit; --depth stops
+at the given level.`,
+		Example: `  yougile tasks tree ID-100
+  yougile tasks tree ID-100 --depth 2 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if depth < 0 {
+				return fmt.Errorf("--depth must not be negative")
+			}
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			task, err := getTask(ctx, api, id)
+			if err != nil {
+				return err
+			}
+			root := newTaskNode(task)
+			root.Subtasks = subtaskTree(ctx, api, task, depth, map[string]bool{})
+
+			var b strings.Builder
+			b.WriteString(root.label() + "\n")
+			writeTree(&b, root.Subtasks, "")
+			if done, total := countTree(root.Subtasks); total > 0 {
+				fmt.Fprintf(&b, "\n%d/%d subtasks done\n", done, total)
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: root, Message: strings.TrimRight(b.String(), "\n")})
+		},
+	}
+	c.Flags().IntVar(&depth, "depth", 0, "levels of subtasks to show (0 for all)")
+	return c
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Task IDs of the subtask tests: epic has design and build, design has
+// mockups, and mockups points back at epic.
+const (
+	epicID    = "e0000000-0000-4000-8000-000000000001"
+	designID  = "e0000000-0000-4000-8000-000000000002"
+	buildID   = "e0000000-0000-4000-8000-000000000003"
+	mockupsID = "e0000000-0000-4000-8000-000000000004"
+	newTaskID = "e0000000-0000-4000-8000-000000000009"
+)
+
+func subtasksServer(t *testing.T) (*httptest.Server, map[string]map[string]interface{}) {
+	t.Helper()
+	tasks := map[string]string{
+		epicID:    fmt.Sprintf(`{"id":%q,"title":"Epic","idTaskCommon":"ID-1","subtasks":[%q,%q]}`, epicID, designID, buildID),
+		designID:  fmt.Sprintf(`{"id":%q,"title":"Design","idTaskCommon":"ID-2","completed":true,"subtasks":[%q]}`, designID, mockupsID),
+		buildID:   fmt.Sprintf(`{"id":%q,"title":"Build","idTaskCommon":"ID-3"}`, buildID),
+		mockupsID: fmt.Sprintf(`{"id":%q,"title":"Mockups","idTaskCommon":"ID-4","subtasks":[%q]}`, mockupsID, epicID),
+	}
+	puts := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id := strings.TrimPrefix(r.URL.Path, "/api-v2/tasks/")
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"`+newTaskID+`"}`)
+		case http.MethodPut:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			puts[id] = body
+			_, _ = io.WriteString(w, `{"id":"`+id+`"}`)
+		default:
+			body, ok := tasks[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, puts
+}
+
+func putSubtasks(puts map[string]map[string]interface{}, id string) []string {
+	var ids []string
+	list, _ := puts[id]["subtasks"].([]interface{})
+	for _, v := range list {
+		ids = append(ids, v.(string))
+	}
+	return ids
+}
+
+func TestTasksTree_RendersRecursivelyAndStopsAtCycles(t *testing.T) {
+	srv, _ := subtasksServer(t)
+	out := runCmd(t, NewTasksTreeCmd(statusGlobals(t, srv.URL)), epicID)
+	want := `[ ] Epic (ID-1)
+├── [x] Design (ID-2)
+│   └── [ ] Mockups (ID-4)
+│       └── ↺ ` + epicID + ` (already shown above)
+└── [ ] Build (ID-3)
+
+1/3 subtasks done
+`
+	if out != want {
+		t.Errorf("tree =\n%s\nwant\n%s", out, want)
+	}
+
+	out = runCmd(t, NewTasksTreeCmd(statusGlobals(t, srv.URL)), epicID, "--depth", "1")
+	if strings.Contains(out, "Mockups") || !strings.Contains(out, "Build") {
+		t.Errorf("--depth 1 tree =\n%s", out)
+	}
+}
+
+func TestTasksSubtasks_AddSkipsDuplicatesAndRemove(t *testing.T) {
+	srv, puts := subtasksServer(t)
+	g := statusGlobals(t, srv.URL)
+	runCmd(t, NewTasksSubtasksCmd(g), "add", epicID, buildID, mockupsID)
+	if got, want := putSubtasks(puts, epicID), []string{designID, buildID, mockupsID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subtasks after add = %v, want %v", got, want)
+	}
+
+	runCmd(t, NewTasksSubtasksCmd(g), "remove", epicID, designID)
+	if got, want := putSubtasks(puts, epicID), []string{buildID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("subtasks after remove = %v, want %v", got, want)
+	}
+
+	c := NewTasksSubtasksCmd(g)
+	c.SetArgs([]string{"remove", epicID, mockupsID})
+	c.SetOut(io.Discard)
+	if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "not a subtask") {
+		t.Errorf("err = %v, want not a subtask", err)
+	}
+}
+
+func TestTasksCreate_ParentAppendsSubtask(t *testing.T) {
+	srv, puts := subtasksServer(t)
+	out := runCmd(t, NewTasksCreateCmd(statusGlobals(t, srv.URL)), "--title", "Deploy", "--parent", epicID)
+	if got, want := putSubtasks(puts, epicID), []string{designID, buildID, newTaskID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parent subtasks = %v, want %v", got, want)
+	}
+	if !strings.Contains(out, "subtask of "+epicID) {
+		t.Errorf("output = %q", out)
+	}
+}
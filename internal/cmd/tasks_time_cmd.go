@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/angolovin/yougile-cli/internal/output"
+	"github.com/angolovin/yougile-cli/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// editTimeTracking changes the task's planned and worked hours with a guarded
+// read-modify-write (see editTaskField): plan and work are sent together, so
+// the one not being changed is copied from the task.
+func editTimeTracking(ctx context.Context, api *client.ClientWithResponses, id string, edit func(client.TimeTracking) client.TimeTracking) (client.TimeTracking, error) {
+	return editTaskField(ctx, api, id, "time tracking",
+		func(t *client.TaskDto) client.TimeTracking {
+			if t.TimeTracking == nil {
+				return client.TimeTracking{}
+			}
+			return *t.TimeTracking
+		},
+		func(tt client.TimeTracking) (client.TimeTracking, error) { return edit(tt), nil },
+		func(body *client.UpdateTaskDto, tt client.TimeTracking) {
+			body.TimeTracking = &client.UpdateTimeTracking{Plan: float32Ptr(tt.Plan), Work: float32Ptr(tt.Work)}
+		})
+}
+
+// timeTrackingMessage is the human summary of a task's time tracking.
+func timeTrackingMessage(task string, tt client.TimeTracking) string {
+	if tt.Plan == 0 && tt.Work == 0 {
+		return fmt.Sprintf("Task %s: no time tracked", task)
+	}
+	return fmt.Sprintf("Task %s: %s", task, formatTimeTracking(&tt))
+}
+
+// NewTasksTimeShowCmd returns the "tasks time show" command.
+func NewTasksTimeShowCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <task>",
+		Short: "Show a task's planned and worked hours",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			task, err := getTask(ctx, api, id)
+			if err != nil {
+				return err
+			}
+			tt := client.TimeTracking{}
+			if task.TimeTracking != nil {
+				tt = *task.TimeTracking
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: tt, Message: timeTrackingMessage(args[0], tt)})
+		},
+	}
+}
+
+// NewTasksTimePlanCmd returns the "tasks time plan" command.
+func NewTasksTimePlanCmd(g *Globals) *cobra.Command {
+	return &cobra.Command{
+		Use:     "plan <task> <hours>",
+		Short:   "Set a task's planned hours",
+		Example: `  yougile tasks time plan ID-123 6h`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			plan, err := parseHours(args[1])
+			if err != nil {
+				return err
+			}
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			tt, err := editTimeTracking(ctx, api, id, func(tt client.TimeTracking) client.TimeTracking {
+				tt.Plan = plan
+				return tt
+			})
+			if err != nil {
+				return err
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: tt, Message: timeTrackingMessage(args[0], tt)})
+		},
+	}
+}
+
+// NewTasksTimeLogCmd returns the "tasks time log" command.
+func NewTasksTimeLogCmd(g *Globals) *cobra.Command {
+	var set bool
+	c := &cobra.Command{
+		Use:   "log <task> <hours>",
+		Short: "Add worked hours to a task",
+		Long: `Add worked hours to a task: the hours are added to the time already
+worked. --set replaces the total instead, e.g. to correct a mistake.`,
+		Example: `  yougile tasks time log ID-123 1h30m
+  yougile tasks time log ID-123 0.5
+  yougile tasks time log ID-123 4 --set`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(c *cobra.Command, args []string) error {
+			hours, err := parseHours(args[1])
+			if err != nil {
+				return err
+			}
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			id, err := g.newNames(ctx, api, s).resolveTask(args[0])
+			if err != nil {
+				return err
+			}
+			tt, err := editTimeTracking(ctx, api, id, func(tt client.TimeTracking) client.TimeTracking {
+				if set {
+					tt.Work = hours
+				} else {
+					tt.Work += hours
+				}
+				return tt
+			})
+			if err != nil {
+				return err
+			}
+			return g.print(c.OutOrStdout(), output.Result{Value: tt, Message: timeTrackingMessage(args[0], tt)})
+		},
+	}
+	c.Flags().BoolVar(&set, "set", false, "replace the worked hours instead of adding to them")
+	return c
+}
+
+// NewTasksTimeCmd returns the "tasks time" parent command.
+func NewTasksTimeCmd(g *Globals) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "time",
+		Short: "Show and change a task's time tracking",
+		Long: `Show and change a task's planned and worked hours. Hours are a number
+(1.5) or a duration (90m, 1h30m).
+
+Plan and work are written together: the task is read again right before
+writing, and if its hours changed meanwhile (e.g. another "time log"),
+nothing is written.`,
+	}
+	c.AddCommand(NewTasksTimeShowCmd(g))
+	c.AddCommand(NewTasksTimePlanCmd(g))
+	c.AddCommand(NewTasksTimeLogCmd(g))
+	return c
+}
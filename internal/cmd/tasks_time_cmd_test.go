@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const trackedTask = `{"id":"` + editTaskID + `","title":"Invoice","timeTracking":{"plan":6,"work":2.5}}`
+
+func TestTasksTimeLog_AddsToWork(t *testing.T) {
+	srv, put := taskEditServer(t, trackedTask)
+	out := runCmd(t, NewTasksTimeCmd(statusGlobals(t, srv.URL)), "log", editTaskID, "1h30m")
+	tt, _ := (*put)["timeTracking"].(map[string]interface{})
+	if tt["work"] != 4.0 || tt["plan"] != 6.0 {
+		t.Errorf("timeTracking = %v, want plan 6 and work 4", tt)
+	}
+	if !strings.Contains(out, "4h of 6h planned") {
+		t.Errorf("output = %q", out)
+	}
+
+	srv, put = taskEditServer(t, trackedTask)
+	runCmd(t, NewTasksTimeCmd(statusGlobals(t, srv.URL)), "log", editTaskID, "0.5", "--set")
+	if tt, _ := (*put)["timeTracking"].(map[string]interface{}); tt["work"] != 0.5 {
+		t.Errorf("timeTracking with --set = %v, want work 0.5", tt)
+	}
+}
+
+func TestTasksTimePlan_KeepsWork(t *testing.T) {
+	srv, put := taskEditServer(t, trackedTask)
+	runCmd(t, NewTasksTimeCmd(statusGlobals(t, srv.URL)), "plan", editTaskID, "8")
+	tt, _ := (*put)["timeTracking"].(map[string]interface{})
+	if tt["work"] != 2.5 || tt["plan"] != 8.0 {
+		t.Errorf("timeTracking = %v, want plan 8 and work 2.5", tt)
+	}
+}
+
+func TestTasksTimeLog_AbortsWhenTaskChanged(t *testing.T) {
+	logged := strings.Replace(trackedTask, `"work":2.5`, `"work":3.5`, 1)
+	srv, put := taskEditServer(t, trackedTask, logged)
+	c := NewTasksTimeCmd(statusGlobals(t, srv.URL))
+	c.SetArgs([]string{"log", editTaskID, "1"})
+	c.SetOut(io.Discard)
+	if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "changed while editing") {
+		t.Errorf("err = %v, want changed while editing", err)
+	}
+	if *put != nil {
+		t.Errorf("wrote %v over the hours logged meanwhile", *put)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -15,69 +14,71 @@ func strPtr(s string) *string       { return &s }
 func boolPtr(b bool) *bool           { return &b }
 
 // NewUsersListCmd returns the "users list" command.
-func NewUsersListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUsersListCmd(g *Globals) *cobra.Command {
 	var limit, offset int
+	var all bool
 	var email, projectID string
 
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List users",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
 			if err != nil {
 				return err
 			}
 
-			params := &client.UserControllerSearchParams{}
-			if limit > 0 {
-				params.Limit = float32Ptr(float32(limit))
-			}
-			if offset > 0 {
-				params.Offset = float32Ptr(float32(offset))
-			}
+			params := client.UserControllerSearchParams{}
 			if email != "" {
 				params.Email = strPtr(email)
 			}
 			if projectID != "" {
-				params.ProjectId = strPtr(projectID)
-			}
-
-			resp, err := api.UserControllerSearchWithResponse(context.Background(), params)
-			if err != nil {
-				return fmt.Errorf("list users: %w", err)
-			}
-			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list users: HTTP %s", resp.HTTPResponse.Status)
-			}
-			if resp.JSON200 == nil {
-				return fmt.Errorf("list users: empty response")
+				id, err := g.newNames(context.Background(), api, s).resolveProject(projectID)
+				if err != nil {
+					return err
+				}
+				params.ProjectId = strPtr(id)
 			}
 
-			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			headers := []string{"ID", "Email", "Admin"}
-			rows := make([][]string, 0, len(resp.JSON200.Content))
-			for _, u := range resp.JSON200.Content {
-				admin := "no"
-				if u.IsAdmin != nil && *u.IsAdmin {
-					admin = "yes"
+			fetch := func(ctx context.Context, limit, offset int) ([]client.UserListDtoBase, client.PagingMetadata, error) {
+				p := params
+				p.Limit, p.Offset = pageParams(limit, offset)
+				resp, err := api.UserControllerSearchWithResponse(ctx, &p)
+				if err != nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list users: %w", err)
 				}
-				rows = append(rows, []string{u.Id, u.Email, admin})
-			}
-			return output.PrintTable(out, headers, rows)
+				if resp.HTTPResponse.StatusCode != 200 {
+					return nil, client.PagingMetadata{}, apiError("list users", resp.HTTPResponse, resp.Body)
+				}
+				if resp.JSON200 == nil {
+					return nil, client.PagingMetadata{}, fmt.Errorf("list users: empty response")
+				}
+				return resp.JSON200.Content, resp.JSON200.Paging, nil
+			}
+			return listPages(context.Background(), g, cmd.OutOrStdout(), limit, offset, all, fetch, func(users []client.UserListDtoBase, paging client.PagingMetadata) output.Result {
+				headers := []string{"ID", "Email", "Admin"}
+				rows := make([][]string, 0, len(users))
+				for _, u := range users {
+					admin := "no"
+					if u.IsAdmin != nil && *u.IsAdmin {
+						admin = "yes"
+					}
+					rows = append(rows, []string{u.Id, u.Email, admin})
+				}
+				return output.Result{Value: client.UserListDto{Content: users, Paging: paging}, Items: users, Table: output.NewTable(headers, rows)}
+			})
 		},
 	}
 	c.Flags().IntVar(&limit, "limit", 50, "max items to return")
 	c.Flags().IntVar(&offset, "offset", 0, "offset for pagination")
+	c.Flags().BoolVar(&all, "all", false, "fetch all pages (--limit sets the page size); ndjson, csv and tsv print each page as it arrives")
 	c.Flags().StringVar(&email, "email", "", "filter by email")
-	c.Flags().StringVar(&projectID, "project-id", "", "filter by project ID")
+	refFlag(c, &projectID, "project", "filter by project (ID or name)")
 	return c
 }
 
 // NewUsersCreateCmd returns the "users create" command.
-func NewUsersCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUsersCreateCmd(g *Globals) *cobra.Command {
 	var email string
 	var isAdmin bool
 	c := &cobra.Command{
@@ -87,7 +88,7 @@ func NewUsersCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 			if email == "" {
 				return fmt.Errorf("email is required (--email)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -100,17 +101,13 @@ func NewUsersCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 				return fmt.Errorf("create user: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create user: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create user", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
+			if resp.JSON201 == nil {
+				return nil
 			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "User created: id=%s\n", resp.JSON201.Id)
-				return err
-			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("User created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&email, "email", "", "user email")
@@ -120,18 +117,21 @@ func NewUsersCreateCmd(resolvePath func() (string, error), outputJSON func() boo
 }
 
 // NewUsersUpdateCmd returns the "users update" command.
-func NewUsersUpdateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUsersUpdateCmd(g *Globals) *cobra.Command {
 	var isAdmin bool
 	c := &cobra.Command{
 		Use:   "update [id]",
 		Short: "Update a user",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveUser(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			body := client.UserControllerUpdateJSONRequestBody{}
 			if cmd.Flags().Changed("admin") {
 				body.IsAdmin = &isAdmin
@@ -141,17 +141,13 @@ func NewUsersUpdateCmd(resolvePath func() (string, error), outputJSON func() boo
 				return fmt.Errorf("update user: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("update user: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("update user", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			if resp.JSON200 != nil {
-				_, err = fmt.Fprintf(out, "User updated: id=%s\n", id)
-				return err
+			if resp.JSON200 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("User updated: id=%s", id)})
 		},
 	}
 	c.Flags().BoolVar(&isAdmin, "admin", false, "set admin rights")
@@ -159,79 +155,76 @@ func NewUsersUpdateCmd(resolvePath func() (string, error), outputJSON func() boo
 }
 
 // NewUsersDeleteCmd returns the "users delete" command.
-func NewUsersDeleteCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUsersDeleteCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete [id]",
 		Short: "Delete a user",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveUser(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 			resp, err := api.UserControllerDeleteWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("delete user: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("delete user: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("delete user", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON200 != nil {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			_, err = fmt.Fprintf(out, "User deleted: id=%s\n", id)
-			return err
+			return g.print(out, output.Result{Value: resp.JSON200, Message: fmt.Sprintf("User deleted: id=%s", id)})
 		},
 	}
 }
 
 // NewUserGetCmd returns the "users get" command.
-func NewUserGetCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUserGetCmd(g *Globals) *cobra.Command {
 	return &cobra.Command{
 		Use:   "get [id]",
-		Short: "Get user by ID",
+		Short: `Get user by ID, email, real name or "me"`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, s, api, err := loadSession(g)
+			if err != nil {
+				return err
+			}
+			id, err := g.newNames(context.Background(), api, s).resolveUser(args[0])
 			if err != nil {
 				return err
 			}
-			id := args[0]
 
 			resp, err := api.UserControllerGetWithResponse(context.Background(), id)
 			if err != nil {
 				return fmt.Errorf("get user: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("get user: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("get user", resp.HTTPResponse, resp.Body)
 			}
 			if resp.JSON200 == nil {
 				return fmt.Errorf("get user: empty response")
 			}
 
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200})
 		},
 	}
 }
 
 // NewUsersCmd returns the "users" parent command.
-func NewUsersCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewUsersCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "users",
 		Short: "Manage users",
 	}
-	c.AddCommand(NewUsersListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewUserGetCmd(resolvePath, outputJSON))
-	c.AddCommand(NewUsersCreateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewUsersUpdateCmd(resolvePath, outputJSON))
-	c.AddCommand(NewUsersDeleteCmd(resolvePath, outputJSON))
+	c.AddCommand(NewUsersListCmd(g))
+	c.AddCommand(NewUserGetCmd(g))
+	c.AddCommand(NewUsersCreateCmd(g))
+	c.AddCommand(NewUsersUpdateCmd(g))
+	c.AddCommand(NewUsersDeleteCmd(g))
 	return c
 }
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/angolovin/yougile-cli/internal/output"
@@ -11,13 +10,13 @@ import (
 )
 
 // NewWebhooksListCmd returns the "webhooks list" command.
-func NewWebhooksListCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewWebhooksListCmd(g *Globals) *cobra.Command {
 	var includeDeleted bool
 	c := &cobra.Command{
 		Use:   "list",
 		Short: "List webhooks",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -30,19 +29,13 @@ func NewWebhooksListCmd(resolvePath func() (string, error), outputJSON func() bo
 				return fmt.Errorf("list webhooks: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 200 {
-				return fmt.Errorf("list webhooks: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("list webhooks", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() {
-				return output.PrintJSON(out, resp.JSON200)
-			}
 			if resp.JSON200 == nil {
-				_, err = fmt.Fprintln(out, "{}")
-				return err
+				return g.print(out, output.Result{Value: struct{}{}})
 			}
-			enc := json.NewEncoder(out)
-			enc.SetIndent("", "  ")
-			return enc.Encode(resp.JSON200)
+			return g.print(out, output.Result{Value: resp.JSON200, Items: resp.JSON200})
 		},
 	}
 	c.Flags().BoolVar(&includeDeleted, "include-deleted", false, "include deleted webhooks")
@@ -50,7 +43,7 @@ func NewWebhooksListCmd(resolvePath func() (string, error), outputJSON func() bo
 }
 
 // NewWebhooksCreateCmd returns the "webhooks create" command.
-func NewWebhooksCreateCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewWebhooksCreateCmd(g *Globals) *cobra.Command {
 	var event, url string
 	c := &cobra.Command{
 		Use:   "create",
@@ -59,7 +52,7 @@ func NewWebhooksCreateCmd(resolvePath func() (string, error), outputJSON func()
 			if event == "" || url == "" {
 				return fmt.Errorf("event and url are required (--event, --url)")
 			}
-			_, api, err := loadConfigAndClient(resolvePath)
+			_, api, err := loadConfigAndClient(g)
 			if err != nil {
 				return err
 			}
@@ -73,17 +66,13 @@ func NewWebhooksCreateCmd(resolvePath func() (string, error), outputJSON func()
 				return fmt.Errorf("create webhook: %w", err)
 			}
 			if resp.HTTPResponse.StatusCode != 201 {
-				return fmt.Errorf("create webhook: HTTP %s", resp.HTTPResponse.Status)
+				return apiError("create webhook", resp.HTTPResponse, resp.Body)
 			}
 			out := cmd.OutOrStdout()
-			if outputJSON() && resp.JSON201 != nil {
-				return output.PrintJSON(out, resp.JSON201)
-			}
-			if resp.JSON201 != nil {
-				_, err = fmt.Fprintf(out, "Webhook created: id=%s\n", resp.JSON201.Id)
-				return err
+			if resp.JSON201 == nil {
+				return nil
 			}
-			return nil
+			return g.print(out, output.Result{Value: resp.JSON201, Message: fmt.Sprintf("Webhook created: id=%s", resp.JSON201.Id)})
 		},
 	}
 	c.Flags().StringVar(&event, "event", "", "event pattern (e.g. task-*, .*)")
@@ -94,12 +83,12 @@ func NewWebhooksCreateCmd(resolvePath func() (string, error), outputJSON func()
 }
 
 // NewWebhooksCmd returns the "webhooks" parent command.
-func NewWebhooksCmd(resolvePath func() (string, error), outputJSON func() bool) *cobra.Command {
+func NewWebhooksCmd(g *Globals) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "webhooks",
 		Short: "Manage webhooks",
 	}
-	c.AddCommand(NewWebhooksListCmd(resolvePath, outputJSON))
-	c.AddCommand(NewWebhooksCreateCmd(resolvePath, outputJSON))
+	c.AddCommand(NewWebhooksListCmd(g))
+	c.AddCommand(NewWebhooksCreateCmd(g))
 	return c
 }
@@ -4,26 +4,57 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
 
 const defaultBaseURL = "https://ru.yougile.com"
 
+// DefaultProfile is the name of the profile stored in the top-level
+// base_url/api_key keys (the only profile in configs without a profiles map).
+const DefaultProfile = "default"
+
 // DefaultBaseURL returns the default YouGile API base URL.
 func DefaultBaseURL() string {
 	return defaultBaseURL
 }
 
 // Config holds YouGile CLI configuration.
+// The top-level BaseURL/APIKey form the "default" profile; Profiles holds
+// additional named profiles, e.g. one per YouGile company.
 type Config struct {
-	BaseURL string `yaml:"base_url"`
-	APIKey  string `yaml:"api_key"`
+	BaseURL        string             `yaml:"base_url"`
+	APIKey         string             `yaml:"api_key"`
+	Email          string             `yaml:"email,omitempty"`
+	CurrentProfile string             `yaml:"current_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+	// CredentialStore is where API keys are kept: "config" (default, api_key
+	// in this file), "keyring" (OS keyring) or "file" (encrypted file).
+	CredentialStore string `yaml:"credential_store,omitempty"`
+	// CacheTTL overrides how long cached lists (users, columns, ...) are
+	// used before they are refetched, e.g. "30m".
+	CacheTTL string `yaml:"cache_ttl,omitempty"`
+	// Timezone is the IANA zone dates are shown and parsed in, e.g.
+	// "Europe/Moscow"; empty means the system zone.
+	Timezone string `yaml:"timezone,omitempty"`
+
+	// defaulted marks profiles whose base_url was filled in by Load.
+	defaulted map[string]bool
+}
+
+// Profile is one set of connection settings.
+type Profile struct {
+	BaseURL string `yaml:"base_url,omitempty"`
+	APIKey  string `yaml:"api_key,omitempty"`
+	// Email is the account the key was created for (set by auth login).
+	Email string `yaml:"email,omitempty"`
 }
 
 // Load reads and parses the config file at path.
 // Returns error if the file doesn't exist or is invalid.
-// If base_url is empty, it is set to defaultBaseURL.
+// If base_url is empty (top-level or in a profile), it is set to defaultBaseURL.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -35,8 +66,17 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
+	cfg.defaulted = map[string]bool{}
 	if cfg.BaseURL == "" {
 		cfg.BaseURL = defaultBaseURL
+		cfg.defaulted[DefaultProfile] = true
+	}
+	for name, p := range cfg.Profiles {
+		cfg.defaulted[name] = p.BaseURL == ""
+		if p.BaseURL == "" {
+			p.BaseURL = defaultBaseURL
+			cfg.Profiles[name] = p
+		}
 	}
 
 	return &cfg, nil
@@ -60,3 +100,113 @@ func Save(path string, cfg *Config) error {
 	}
 	return nil
 }
+
+// baseURLDefaulted reports whether the named profile had no base_url in the file.
+func (c *Config) baseURLDefaulted(name string) bool {
+	return c != nil && c.defaulted[name]
+}
+
+// CredentialsPath returns the encrypted credentials file used by the "file"
+// credential store, kept next to the config file at configPath.
+func CredentialsPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "credentials.age")
+}
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]*$`)
+
+// ValidateProfileName checks that name can be used as a profile name. Names
+// become directory and keyring entry names, so path separators, "." and ".."
+// are rejected.
+func ValidateProfileName(name string) error {
+	if name == "." || name == ".." || !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-', '_' and '.', not starting with '.'", name)
+	}
+	return nil
+}
+
+// ActiveProfileName returns name if set, otherwise current_profile, otherwise DefaultProfile.
+func (c *Config) ActiveProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// Profile returns the profile selected by name (see ActiveProfileName)
+// and its resolved name. Returns error if a named profile doesn't exist.
+func (c *Config) Profile(name string) (Profile, string, error) {
+	name = c.ActiveProfileName(name)
+	if name == DefaultProfile {
+		if p, ok := c.Profiles[DefaultProfile]; ok {
+			return p, name, nil
+		}
+		return Profile{BaseURL: c.BaseURL, APIKey: c.APIKey, Email: c.Email}, name, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, name, fmt.Errorf("profile %q not found", name)
+	}
+	return p, name, nil
+}
+
+// HasProfile reports whether a profile with name exists.
+// The default profile always exists.
+func (c *Config) HasProfile(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+	_, ok := c.Profiles[name]
+	return ok
+}
+
+// SetProfile creates or replaces the named profile.
+// The default profile is written to the top-level base_url/api_key.
+func (c *Config) SetProfile(name string, p Profile) {
+	if p.BaseURL == "" {
+		p.BaseURL = defaultBaseURL
+	}
+	if _, ok := c.Profiles[name]; ok || name != DefaultProfile {
+		if c.Profiles == nil {
+			c.Profiles = map[string]Profile{}
+		}
+		c.Profiles[name] = p
+		return
+	}
+	c.BaseURL = p.BaseURL
+	c.APIKey = p.APIKey
+	c.Email = p.Email
+}
+
+// RemoveProfile deletes the named profile. Removing the default profile clears
+// the top-level api_key. If the removed profile was current, current_profile is reset.
+func (c *Config) RemoveProfile(name string) error {
+	if !c.HasProfile(name) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if _, ok := c.Profiles[name]; ok {
+		delete(c.Profiles, name)
+	} else {
+		c.APIKey = ""
+	}
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+	return nil
+}
+
+// ProfileNames returns all profile names, sorted. The default profile is
+// listed if it has an api_key or if there are no named profiles.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles)+1)
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	if _, ok := c.Profiles[DefaultProfile]; !ok && (c.APIKey != "" || len(c.Profiles) == 0) {
+		names = append(names, DefaultProfile)
+	}
+	sort.Strings(names)
+	return names
+}
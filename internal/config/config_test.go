@@ -73,3 +73,88 @@ api_key: "key"
 		t.Errorf("BaseURL = %q, want %q", cfg.BaseURL, defaultBaseURL)
 	}
 }
+
+func TestLoad_Profiles_SelectsCurrentProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(path, []byte(`
+api_key: "default-key"
+current_profile: work
+profiles:
+  work:
+    api_key: "work-key"
+  other:
+    base_url: "https://other.example"
+    api_key: "other-key"
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	p, name, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if name != "work" || p.APIKey != "work-key" || p.BaseURL != defaultBaseURL {
+		t.Errorf("Profile(\"\") = %+v, %q", p, name)
+	}
+	p, _, err = cfg.Profile("other")
+	if err != nil || p.BaseURL != "https://other.example" {
+		t.Errorf("Profile(other) = %+v, %v", p, err)
+	}
+	p, _, err = cfg.Profile(DefaultProfile)
+	if err != nil || p.APIKey != "default-key" {
+		t.Errorf("Profile(default) = %+v, %v", p, err)
+	}
+	if _, _, err := cfg.Profile("missing"); err == nil {
+		t.Error("expected error for missing profile")
+	}
+}
+
+func TestSetProfile_DefaultWritesTopLevel_NamedWritesMap(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetProfile(DefaultProfile, Profile{APIKey: "k1"})
+	cfg.SetProfile("work", Profile{BaseURL: "https://w.example", APIKey: "k2"})
+
+	if cfg.APIKey != "k1" || cfg.BaseURL != defaultBaseURL {
+		t.Errorf("top-level = %q %q", cfg.BaseURL, cfg.APIKey)
+	}
+	if cfg.Profiles["work"].APIKey != "k2" {
+		t.Errorf("Profiles = %+v", cfg.Profiles)
+	}
+	names := cfg.ProfileNames()
+	if len(names) != 2 || names[0] != DefaultProfile || names[1] != "work" {
+		t.Errorf("ProfileNames = %v", names)
+	}
+}
+
+func TestRemoveProfile_Current_ResetsCurrentProfile(t *testing.T) {
+	cfg := &Config{CurrentProfile: "work", Profiles: map[string]Profile{"work": {APIKey: "k"}}}
+
+	if err := cfg.RemoveProfile("work"); err != nil {
+		t.Fatalf("RemoveProfile: %v", err)
+	}
+	if cfg.CurrentProfile != "" || len(cfg.Profiles) != 0 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if err := cfg.RemoveProfile("work"); err == nil {
+		t.Error("expected error removing missing profile")
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"default", "acme", "acme.io", "team_2", "-x"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", "../..", "a/b", `a\b`, ".hidden", "a b"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) = nil, want error", name)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	_ "time/tzdata" // timezone must work where the OS has no zoneinfo (Windows)
+)
+
+// Environment variables that override config file values.
+const (
+	EnvAPIKey  = "YOUGILE_API_KEY"
+	EnvBaseURL = "YOUGILE_BASE_URL"
+	EnvProfile = "YOUGILE_PROFILE"
+	EnvConfig  = "YOUGILE_CONFIG"
+	// EnvPassphrase unlocks the encrypted credential store without a prompt.
+	EnvPassphrase = "YOUGILE_PASSPHRASE"
+)
+
+const (
+	defaultConfigDir  = "yougile-cli"
+	defaultConfigFile = "config.yaml"
+)
+
+// Source tells where an effective setting came from.
+// Precedence is flag > env > file > default.
+type Source string
+
+// Setting sources, highest precedence first.
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceFile    Source = "file"
+	SourceDefault Source = "default"
+	// SourceStore is the credential_store backend (keyring or encrypted file).
+	SourceStore Source = "credential_store"
+	SourceUnset Source = ""
+)
+
+// DefaultPath returns the default config file path under the user config dir.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, defaultConfigDir, defaultConfigFile), nil
+}
+
+// ResolvePath returns the config file path: flag if set, then $YOUGILE_CONFIG, then DefaultPath.
+func ResolvePath(flag string) (string, Source, error) {
+	if flag != "" {
+		return flag, SourceFlag, nil
+	}
+	if env := os.Getenv(EnvConfig); env != "" {
+		return env, SourceEnv, nil
+	}
+	path, err := DefaultPath()
+	return path, SourceDefault, err
+}
+
+// Settings are the effective connection settings with the source of each value.
+type Settings struct {
+	ConfigPath    string
+	ConfigLoaded  bool // false if the config file does not exist
+	Profile       string
+	ProfileSource Source
+	BaseURL       string
+	BaseURLSource Source
+	APIKey        string
+	APIKeySource  Source
+	Email         string         // account email saved by auth login, if any
+	CacheTTL      time.Duration  // cache_ttl from the config; 0 means the defaults
+	Location      *time.Location // timezone from the config; nil means the system zone
+}
+
+// LoadSettings loads the config file at path, if it exists, and resolves the
+// effective settings (flag > env > file > default):
+//   - profile: profileFlag, $YOUGILE_PROFILE, current_profile, "default"
+//   - base_url: $YOUGILE_BASE_URL, the profile's base_url, DefaultBaseURL
+//   - api_key: $YOUGILE_API_KEY, the profile's api_key
+//
+// A missing file is not an error (cfg is nil). A named profile that is not in
+// the file is an error unless $YOUGILE_API_KEY supplies the key.
+func LoadSettings(path, profileFlag string) (*Config, Settings, error) {
+	s := Settings{ConfigPath: path}
+	cfg, err := Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg, err = nil, nil
+	}
+	if err != nil {
+		return nil, s, err
+	}
+	s.ConfigLoaded = cfg != nil
+	if cfg != nil && cfg.CacheTTL != "" {
+		if s.CacheTTL, err = time.ParseDuration(cfg.CacheTTL); err != nil || s.CacheTTL < 0 {
+			return cfg, s, fmt.Errorf("parse config: cache_ttl %q: want a duration such as 30m", cfg.CacheTTL)
+		}
+	}
+	if cfg != nil && cfg.Timezone != "" {
+		if s.Location, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return cfg, s, fmt.Errorf("parse config: timezone %q: want an IANA zone such as Europe/Moscow", cfg.Timezone)
+		}
+	}
+
+	switch {
+	case profileFlag != "":
+		s.Profile, s.ProfileSource = profileFlag, SourceFlag
+	case os.Getenv(EnvProfile) != "":
+		s.Profile, s.ProfileSource = os.Getenv(EnvProfile), SourceEnv
+	case cfg != nil && cfg.CurrentProfile != "":
+		s.Profile, s.ProfileSource = cfg.CurrentProfile, SourceFile
+	default:
+		s.Profile, s.ProfileSource = DefaultProfile, SourceDefault
+	}
+	if err := ValidateProfileName(s.Profile); err != nil {
+		return cfg, s, err
+	}
+
+	var p Profile
+	if cfg != nil {
+		p, _, err = cfg.Profile(s.Profile)
+		if err != nil && os.Getenv(EnvAPIKey) == "" {
+			return cfg, s, err
+		}
+	}
+
+	switch {
+	case os.Getenv(EnvBaseURL) != "":
+		s.BaseURL, s.BaseURLSource = os.Getenv(EnvBaseURL), SourceEnv
+	case p.BaseURL != "" && !cfg.baseURLDefaulted(s.Profile):
+		s.BaseURL, s.BaseURLSource = p.BaseURL, SourceFile
+	default:
+		s.BaseURL, s.BaseURLSource = defaultBaseURL, SourceDefault
+	}
+
+	s.Email = p.Email
+	switch {
+	case os.Getenv(EnvAPIKey) != "":
+		s.APIKey, s.APIKeySource = os.Getenv(EnvAPIKey), SourceEnv
+	case p.APIKey != "":
+		s.APIKey, s.APIKeySource = p.APIKey, SourceFile
+	}
+
+	return cfg, s, nil
+}
+
+// MissingAPIKeyError explains why no API key could be resolved for s.
+func (s Settings) MissingAPIKeyError() error {
+	if !s.ConfigLoaded {
+		return fmt.Errorf("config file %s not found and %s not set", s.ConfigPath, EnvAPIKey)
+	}
+	return fmt.Errorf("api_key not set in config (profile %s)", s.Profile)
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{EnvAPIKey, EnvBaseURL, EnvProfile, EnvConfig} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestLoadSettings_EnvOverridesFile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, "base_url: https://file.example\napi_key: file-key\n")
+	t.Setenv(EnvAPIKey, "env-key")
+
+	_, s, err := LoadSettings(path, "")
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.APIKey != "env-key" || s.APIKeySource != SourceEnv {
+		t.Errorf("api key = %q (%s), want env-key (env)", s.APIKey, s.APIKeySource)
+	}
+	if s.BaseURL != "https://file.example" || s.BaseURLSource != SourceFile {
+		t.Errorf("base url = %q (%s), want file value", s.BaseURL, s.BaseURLSource)
+	}
+	if s.Profile != DefaultProfile || s.ProfileSource != SourceDefault {
+		t.Errorf("profile = %q (%s), want default", s.Profile, s.ProfileSource)
+	}
+}
+
+func TestLoadSettings_ProfileFlagBeatsEnv(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, `
+api_key: default-key
+profiles:
+  work:
+    api_key: work-key
+  home:
+    api_key: home-key
+`)
+	t.Setenv(EnvProfile, "home")
+
+	_, s, err := LoadSettings(path, "work")
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.Profile != "work" || s.ProfileSource != SourceFlag || s.APIKey != "work-key" {
+		t.Errorf("settings = %+v, want profile work from flag", s)
+	}
+
+	_, s, err = LoadSettings(path, "")
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.Profile != "home" || s.ProfileSource != SourceEnv || s.APIKey != "home-key" {
+		t.Errorf("settings = %+v, want profile home from env", s)
+	}
+}
+
+func TestLoadSettings_MissingFile_UsesEnvAndDefaults(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvAPIKey, "env-key")
+
+	cfg, s, err := LoadSettings(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if cfg != nil || s.ConfigLoaded {
+		t.Error("missing file should yield nil config")
+	}
+	if s.APIKey != "env-key" || s.BaseURL != defaultBaseURL || s.BaseURLSource != SourceDefault {
+		t.Errorf("settings = %+v, want env key and default base url", s)
+	}
+}
+
+func TestLoadSettings_DefaultedBaseURL_ReportsDefaultSource(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, "api_key: k\n")
+
+	_, s, err := LoadSettings(path, "")
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if s.BaseURLSource != SourceDefault {
+		t.Errorf("BaseURLSource = %q, want default", s.BaseURLSource)
+	}
+}
+
+func TestLoadSettings_UnknownProfile_Errors(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, "api_key: k\n")
+
+	if _, _, err := LoadSettings(path, "nope"); err == nil {
+		t.Fatal("expected error for unknown profile")
+	}
+}
+
+func TestLoadSettings_InvalidProfileName_ErrorsWithoutConfig(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvAPIKey, "k")
+	missing := filepath.Join(t.TempDir(), "config.yaml")
+
+	if _, _, err := LoadSettings(missing, "../.."); err == nil {
+		t.Error("want error for --profile ../..")
+	}
+	t.Setenv(EnvProfile, "a/b")
+	if _, _, err := LoadSettings(missing, ""); err == nil {
+		t.Error("want error for YOUGILE_PROFILE=a/b")
+	}
+}
+
+func TestLoadSettings_CacheTTL(t *testing.T) {
+	clearEnv(t)
+	_, s, err := LoadSettings(writeConfig(t, "api_key: k\ncache_ttl: 30m\n"), "")
+	if err != nil || s.CacheTTL != 30*time.Minute {
+		t.Errorf("CacheTTL = %v, %v; want 30m", s.CacheTTL, err)
+	}
+	if _, _, err := LoadSettings(writeConfig(t, "api_key: k\ncache_ttl: soon\n"), ""); err == nil {
+		t.Error("want error for invalid cache_ttl")
+	}
+}
+
+func TestLoadSettings_Timezone(t *testing.T) {
+	clearEnv(t)
+	_, s, err := LoadSettings(writeConfig(t, "api_key: k\ntimezone: Asia/Tokyo\n"), "")
+	if err != nil || s.Location == nil || s.Location.String() != "Asia/Tokyo" {
+		t.Errorf("Location = %v, %v; want Asia/Tokyo", s.Location, err)
+	}
+	if _, _, err := LoadSettings(writeConfig(t, "api_key: k\ntimezone: Mars/Base\n"), ""); err == nil {
+		t.Error("want error for unknown timezone")
+	}
+}
+
+func TestResolvePath_FlagThenEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvConfig, "/env/config.yaml")
+
+	if p, src, _ := ResolvePath("/flag/config.yaml"); p != "/flag/config.yaml" || src != SourceFlag {
+		t.Errorf("ResolvePath(flag) = %q, %s", p, src)
+	}
+	if p, src, _ := ResolvePath(""); p != "/env/config.yaml" || src != SourceEnv {
+		t.Errorf("ResolvePath(\"\") = %q, %s", p, src)
+	}
+}
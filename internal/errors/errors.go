@@ -1,7 +1,179 @@
+// Package errors defines CLI error types and exit codes.
 package errors
 
-// Exit code constants for the CLI.
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Exit code constants for the CLI. Each failure class has its own code so
+// scripts can tell e.g. "task doesn't exist" from "YouGile is down".
 const (
-	ExitCodeSuccess = 0
-	ExitCodeError   = 1
+	ExitCodeSuccess     = 0
+	ExitCodeError       = 1 // any other failure
+	ExitCodeConfig      = 2 // config file missing or invalid, api_key not set
+	ExitCodeAuth        = 3 // HTTP 401/403
+	ExitCodeNotFound    = 4 // HTTP 404
+	ExitCodeValidation  = 5 // HTTP 400/422
+	ExitCodeRateLimited = 6 // HTTP 429 (after retries)
+	ExitCodeServer      = 7 // HTTP 5xx
+	ExitCodeNetwork     = 8 // connection failed, DNS, timeout
 )
+
+// ExitCode returns the process exit code for err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		switch code := apiErr.StatusCode; {
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			return ExitCodeAuth
+		case code == http.StatusNotFound:
+			return ExitCodeNotFound
+		case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+			return ExitCodeValidation
+		case code == http.StatusTooManyRequests:
+			return ExitCodeRateLimited
+		case code >= 500:
+			return ExitCodeServer
+		}
+		return ExitCodeError
+	}
+	var cfgErr *ConfigError
+	if stderrors.As(err, &cfgErr) {
+		return ExitCodeConfig
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	if stderrors.As(err, &urlErr) || stderrors.As(err, &netErr) {
+		return ExitCodeNetwork
+	}
+	return ExitCodeError
+}
+
+// ConfigError reports a missing or invalid config. It maps to ExitCodeConfig.
+type ConfigError struct {
+	Err error
+}
+
+// NewConfigError wraps err as a ConfigError.
+func NewConfigError(err error) error {
+	return &ConfigError{Err: err}
+}
+
+func (e *ConfigError) Error() string { return e.Err.Error() }
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+// APIError is a non-success response from the YouGile API.
+// Message holds the explanation from the response body's "error" field
+// (and "message", if the API sent one).
+type APIError struct {
+	Op         string `json:"op"`
+	StatusCode int    `json:"status"`
+	Status     string `json:"status_text"`
+	Method     string `json:"method,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Error returns e.g. "update task: HTTP 400 Bad Request (PUT /api-v2/tasks/1): title must be a string".
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: HTTP %s", e.Op, e.Status)
+	if e.Endpoint != "" {
+		fmt.Fprintf(&b, " (%s %s)", e.Method, e.Endpoint)
+	}
+	if e.Message != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	return b.String()
+}
+
+// AsAPIError returns the first APIError in err's chain, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// FromResponse builds an APIError for op from resp and its already-read body.
+func FromResponse(op string, resp *http.Response, body []byte) *APIError {
+	e := &APIError{Op: op}
+	if resp == nil {
+		return e
+	}
+	e.StatusCode = resp.StatusCode
+	e.Status = resp.Status
+	if e.Status == "" {
+		e.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.Endpoint = resp.Request.URL.Path
+		}
+	}
+	e.Message = parseMessage(resp.StatusCode, body)
+	return e
+}
+
+// parseMessage extracts the explanation from an API error body.
+// The API sends {"error": "..."}; validation errors may also carry
+// "message" as a string or a list of strings. Non-JSON bodies are returned trimmed.
+func parseMessage(code int, body []byte) string {
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return ""
+	}
+	var payload struct {
+		Error   json.RawMessage `json:"error"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return string(body)
+	}
+	var parts []string
+	add := func(s string) {
+		s = strings.TrimSpace(s)
+		if s == "" || s == http.StatusText(code) {
+			return
+		}
+		for _, p := range parts {
+			if p == s {
+				return
+			}
+		}
+		parts = append(parts, s)
+	}
+	for _, raw := range []json.RawMessage{payload.Error, payload.Message} {
+		if len(raw) == 0 {
+			continue
+		}
+		var s string
+		var list []string
+		switch {
+		case json.Unmarshal(raw, &s) == nil:
+			add(s)
+		case json.Unmarshal(raw, &list) == nil:
+			for _, item := range list {
+				add(item)
+			}
+		default:
+			add(string(raw))
+		}
+	}
+	if len(parts) == 0 && len(payload.Error) == 0 && len(payload.Message) == 0 {
+		return string(body)
+	}
+	return strings.Join(parts, "; ")
+}
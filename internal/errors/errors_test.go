@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func testResponse(code int, method, path string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Request:    &http.Request{Method: method, URL: &url.URL{Path: path}},
+	}
+}
+
+func TestFromResponse_ErrorField_UsedAsMessage(t *testing.T) {
+	resp := testResponse(400, "PUT", "/api-v2/tasks/t1")
+	err := FromResponse("update task", resp, []byte(`{"error":"Column not found","statusCode":400}`))
+
+	if err.StatusCode != 400 || err.Method != "PUT" || err.Endpoint != "/api-v2/tasks/t1" {
+		t.Errorf("err = %+v", err)
+	}
+	if err.Message != "Column not found" {
+		t.Errorf("Message = %q, want Column not found", err.Message)
+	}
+	want := "update task: HTTP 400 Bad Request (PUT /api-v2/tasks/t1): Column not found"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFromResponse_ValidationMessages_JoinedWithoutStatusText(t *testing.T) {
+	resp := testResponse(400, "POST", "/api-v2/tasks")
+	body := `{"statusCode":400,"error":"Bad Request","message":["title must be a string","columnId must be a UUID"]}`
+	err := FromResponse("create task", resp, []byte(body))
+
+	if err.Message != "title must be a string; columnId must be a UUID" {
+		t.Errorf("Message = %q", err.Message)
+	}
+}
+
+func TestFromResponse_NonJSONBody_KeptVerbatim(t *testing.T) {
+	resp := testResponse(502, "GET", "/api-v2/task-list")
+	err := FromResponse("list tasks", resp, []byte("  upstream timeout\n"))
+
+	if err.Message != "upstream timeout" {
+		t.Errorf("Message = %q", err.Message)
+	}
+}
+
+func TestFromResponse_EmptyBody_NoMessage(t *testing.T) {
+	resp := testResponse(404, "GET", "/api-v2/tasks/x")
+	err := FromResponse("get task", resp, nil)
+
+	if err.Message != "" {
+		t.Errorf("Message = %q, want empty", err.Message)
+	}
+	if strings.HasSuffix(err.Error(), ": ") {
+		t.Errorf("Error() has dangling separator: %q", err.Error())
+	}
+}
+
+func TestAsAPIError_Wrapped_Found(t *testing.T) {
+	apiErr := FromResponse("get companies", testResponse(401, "POST", "/api-v2/auth/companies"), nil)
+	wrapped := fmt.Errorf("login: %w", apiErr)
+
+	got, ok := AsAPIError(wrapped)
+	if !ok || got != apiErr {
+		t.Errorf("AsAPIError = %v, %v", got, ok)
+	}
+	if _, ok := AsAPIError(fmt.Errorf("plain")); ok {
+		t.Error("AsAPIError on plain error should be false")
+	}
+}
+
+func TestExitCode_ByFailureClass(t *testing.T) {
+	api := func(code int) error {
+		return fmt.Errorf("wrapped: %w", FromResponse("op", testResponse(code, "GET", "/x"), nil))
+	}
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitCodeSuccess},
+		{"plain", fmt.Errorf("boom"), ExitCodeError},
+		{"config", fmt.Errorf("create API client: %w", NewConfigError(fmt.Errorf("api_key not set"))), ExitCodeConfig},
+		{"401", api(401), ExitCodeAuth},
+		{"403", api(403), ExitCodeAuth},
+		{"404", api(404), ExitCodeNotFound},
+		{"400", api(400), ExitCodeValidation},
+		{"429", api(429), ExitCodeRateLimited},
+		{"503", api(503), ExitCodeServer},
+		{"409", api(409), ExitCodeError},
+		{"network", fmt.Errorf("list tasks: %w", &url.Error{Op: "Get", URL: "http://x", Err: fmt.Errorf("connection refused")}), ExitCodeNetwork},
+	}
+	for _, tc := range cases {
+		if got := ExitCode(tc.err); got != tc.want {
+			t.Errorf("%s: ExitCode = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format selected with -o/--output.
+type Format string
+
+// Output formats.
+const (
+	FormatTable  Format = "table"
+	FormatWide   Format = "wide"
+	FormatJSON   Format = "json"
+	FormatYAML   Format = "yaml"
+	FormatCSV    Format = "csv"
+	FormatTSV    Format = "tsv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Prefixes of the formats that carry an argument: -o template='{{.Id}}'
+// and -o jsonpath='{.content[*].id}'.
+const (
+	templatePrefix = "template="
+	jsonPathPrefix = "jsonpath="
+)
+
+// Formats lists the accepted -o/--output values without an argument.
+var Formats = []Format{FormatTable, FormatWide, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatNDJSON}
+
+// ParseFormat returns the Format named s ("" means table). Templates and
+// JSONPath expressions are parsed here so that mistakes are reported before
+// any API call.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatTable, nil
+	}
+	if _, err := newArgPrinter(Format(s)); err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(s, templatePrefix) || strings.HasPrefix(s, jsonPathPrefix) {
+		return Format(s), nil
+	}
+	for _, f := range Formats {
+		if Format(s) == f {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown output format %q (want %s|template=...|jsonpath=...)", s, strings.Join(names, "|"))
+}
+
+// Column is a table column. Wide columns are shown only with -o wide;
+// CSV and TSV always include them. Field is the JSON field of the row DTO
+// the column shows, used by --columns and --sort-by when the header does not
+// spell it (header "Deadline" and field "deadline" match without it).
+type Column struct {
+	Header string
+	Wide   bool
+	Field  string
+}
+
+// Table is the tabular view of a result. Each row has one cell per column.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// NewTable returns a table with the given headers (none of them wide) and rows.
+func NewTable(headers []string, rows [][]string) *Table {
+	cols := make([]Column, len(headers))
+	for i, h := range headers {
+		cols[i] = Column{Header: h}
+	}
+	return &Table{Columns: cols, Rows: rows}
+}
+
+// project returns the headers and rows of the columns to show.
+func (t *Table) project(wide bool) ([]string, [][]string) {
+	keep := make([]int, 0, len(t.Columns))
+	headers := make([]string, 0, len(t.Columns))
+	for i, c := range t.Columns {
+		if wide || !c.Wide {
+			keep = append(keep, i)
+			headers = append(headers, c.Header)
+		}
+	}
+	rows := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = make([]string, len(keep))
+		for j, i := range keep {
+			if i < len(row) {
+				rows[r][j] = row[i]
+			}
+		}
+	}
+	return headers, rows
+}
+
+// Result is what a command prints.
+//   - Value is the full API response, printed by json and yaml.
+//   - Items are the list elements, one per line with ndjson (nil: Value is one item).
+//   - Table is the tabular view for table, wide, csv and tsv.
+//   - Message is the human confirmation of create/update/delete commands.
+//
+// In table mode a result without Table prints Message, or else Value as
+// indented JSON.
+type Result struct {
+	Value   interface{}
+	Items   interface{}
+	Table   *Table
+	Message string
+}
+
+// Printer writes a Result in one output format.
+type Printer interface {
+	Print(w io.Writer, r Result) error
+}
+
+// NewPrinter returns the Printer for f. opts lay out table and wide output;
+// csv and tsv only use NoHeaders.
+func NewPrinter(f Format, opts TableOptions) (Printer, error) {
+	switch f {
+	case FormatTable, "":
+		return tablePrinter{opts: opts}, nil
+	case FormatWide:
+		return tablePrinter{wide: true, opts: opts}, nil
+	case FormatJSON:
+		return jsonPrinter{}, nil
+	case FormatYAML:
+		return yamlPrinter{}, nil
+	case FormatCSV:
+		return delimitedPrinter{format: f, comma: ',', noHeaders: opts.NoHeaders}, nil
+	case FormatTSV:
+		return delimitedPrinter{format: f, comma: '\t', noHeaders: opts.NoHeaders}, nil
+	case FormatNDJSON:
+		return ndjsonPrinter{}, nil
+	}
+	if p, err := newArgPrinter(f); p != nil || err != nil {
+		return p, err
+	}
+	_, err := ParseFormat(string(f))
+	return nil, err
+}
+
+// newArgPrinter returns the printer for template= and jsonpath= formats,
+// or nil for any other format.
+func newArgPrinter(f Format) (Printer, error) {
+	switch s := string(f); {
+	case strings.HasPrefix(s, templatePrefix):
+		tmpl, err := template.New("output").Option("missingkey=zero").Parse(strings.TrimPrefix(s, templatePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse output template: %w", err)
+		}
+		return templatePrinter{tmpl: tmpl}, nil
+	case strings.HasPrefix(s, jsonPathPrefix):
+		jp, err := ParseJSONPath(strings.TrimPrefix(s, jsonPathPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse output jsonpath: %w", err)
+		}
+		return jsonPathPrinter{path: jp}, nil
+	}
+	return nil, nil
+}
+
+type tablePrinter struct {
+	wide bool
+	opts TableOptions
+}
+
+func (p tablePrinter) Print(w io.Writer, r Result) error {
+	switch {
+	case r.Table != nil:
+		headers, rows := r.Table.project(p.wide)
+		return p.opts.Print(w, headers, rows)
+	case r.Message != "":
+		return printMessage(w, r.Message)
+	case !isNil(r.Value):
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.Value)
+	}
+	return nil
+}
+
+type jsonPrinter struct{}
+
+func (jsonPrinter) Print(w io.Writer, r Result) error {
+	if isNil(r.Value) {
+		return printMessage(w, r.Message)
+	}
+	return PrintJSON(w, r.Value)
+}
+
+type yamlPrinter struct{}
+
+func (yamlPrinter) Print(w io.Writer, r Result) error {
+	if isNil(r.Value) {
+		return printMessage(w, r.Message)
+	}
+	// Round-trip through JSON so YAML keys follow the DTOs' json tags.
+	v, err := toGeneric(r.Value)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+	return enc.Close()
+}
+
+type ndjsonPrinter struct{}
+
+func (ndjsonPrinter) Print(w io.Writer, r Result) error {
+	if r.Items == nil {
+		if isNil(r.Value) {
+			return printMessage(w, r.Message)
+		}
+		return PrintJSON(w, r.Value)
+	}
+	return eachItem(r.Items, func(item interface{}) error {
+		return PrintJSON(w, item)
+	})
+}
+
+// templatePrinter executes a Go template on each list item (or on Value)
+// with the typed DTO as dot, so fields use Go names: {{.Id}} {{.Title}}.
+type templatePrinter struct {
+	tmpl *template.Template
+}
+
+func (p templatePrinter) Print(w io.Writer, r Result) error {
+	if r.Items == nil {
+		if isNil(r.Value) {
+			return printMessage(w, r.Message)
+		}
+		return p.execute(w, r.Value)
+	}
+	return eachItem(r.Items, func(item interface{}) error {
+		return p.execute(w, item)
+	})
+}
+
+func (p templatePrinter) execute(w io.Writer, v interface{}) error {
+	var b strings.Builder
+	if err := p.tmpl.Execute(&b, v); err != nil {
+		return fmt.Errorf("execute output template: %w", err)
+	}
+	return writeLine(w, b.String())
+}
+
+// jsonPathPrinter applies a JSONPath template to the JSON form of Value,
+// so paths use the API field names: {.content[*].id}.
+type jsonPathPrinter struct {
+	path *JSONPath
+}
+
+func (p jsonPathPrinter) Print(w io.Writer, r Result) error {
+	if isNil(r.Value) {
+		return printMessage(w, r.Message)
+	}
+	v, err := toGeneric(r.Value)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if err := p.path.Execute(&b, v); err != nil {
+		return err
+	}
+	return writeLine(w, b.String())
+}
+
+// eachItem calls fn for every element of a slice or array (or a pointer to one);
+// any other value is passed to fn as is.
+func eachItem(items interface{}, fn func(interface{}) error) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fn(items)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := fn(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLine writes s followed by a newline unless s already ends with one.
+func writeLine(w io.Writer, s string) error {
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+type delimitedPrinter struct {
+	format    Format
+	comma     rune
+	noHeaders bool
+}
+
+func (p delimitedPrinter) Print(w io.Writer, r Result) error {
+	if r.Table == nil {
+		if r.Message != "" && isNil(r.Value) {
+			return printMessage(w, r.Message)
+		}
+		return fmt.Errorf("output format %s is not supported by this command (use json or yaml)", p.format)
+	}
+	headers, rows := r.Table.project(true)
+	cw := csv.NewWriter(w)
+	cw.Comma = p.comma
+	if !p.noHeaders {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func printMessage(w io.Writer, msg string) error {
+	if msg == "" {
+		return nil
+	}
+	_, err := fmt.Fprintln(w, msg)
+	return err
+}
+
+// toGeneric converts v to maps, slices and scalars via its JSON encoding.
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return out, nil
+}
+
+// isNil reports whether v is nil or a nil pointer, slice or map.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func testResult() Result {
+	items := []item{{ID: "1", Title: "a, b"}, {ID: "2", Title: "Задача"}}
+	return Result{
+		Value: map[string]interface{}{"content": items},
+		Items: items,
+		Table: &Table{
+			Columns: []Column{{Header: "ID"}, {Header: "Title"}, {Header: "Extra", Wide: true}},
+			Rows:    [][]string{{"1", "a, b", "x"}, {"2", "Задача", "y"}},
+		},
+	}
+}
+
+func printResult(t *testing.T, f Format, r Result) string {
+	t.Helper()
+	p, err := NewPrinter(f, TableOptions{})
+	if err != nil {
+		t.Fatalf("NewPrinter(%s): %v", f, err)
+	}
+	var buf bytes.Buffer
+	if err := p.Print(&buf, r); err != nil {
+		t.Fatalf("Print(%s): %v", f, err)
+	}
+	return buf.String()
+}
+
+func TestParseFormat_Unknown_Errors(t *testing.T) {
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+	if f, err := ParseFormat(""); err != nil || f != FormatTable {
+		t.Errorf("ParseFormat(\"\") = %q, %v; want table", f, err)
+	}
+}
+
+func TestTablePrinter_WideColumnsOnlyInWide(t *testing.T) {
+	if out := printResult(t, FormatTable, testResult()); strings.Contains(out, "Extra") {
+		t.Errorf("table output should hide wide columns:\n%s", out)
+	}
+	if out := printResult(t, FormatWide, testResult()); !strings.Contains(out, "Extra") {
+		t.Errorf("wide output should show wide columns:\n%s", out)
+	}
+}
+
+func TestDelimitedPrinters_QuoteAndSeparate(t *testing.T) {
+	csvOut := printResult(t, FormatCSV, testResult())
+	if want := "ID,Title,Extra\n1,\"a, b\",x\n2,Задача,y\n"; csvOut != want {
+		t.Errorf("csv = %q, want %q", csvOut, want)
+	}
+	tsvOut := printResult(t, FormatTSV, testResult())
+	if !strings.HasPrefix(tsvOut, "ID\tTitle\tExtra\n1\ta, b\tx\n") {
+		t.Errorf("tsv = %q", tsvOut)
+	}
+}
+
+func TestDelimitedPrinter_NoTable_Errors(t *testing.T) {
+	p, _ := NewPrinter(FormatCSV, TableOptions{})
+	if err := p.Print(new(bytes.Buffer), Result{Value: item{ID: "1"}}); err == nil {
+		t.Fatal("expected error for csv without a table")
+	}
+}
+
+func TestNDJSONPrinter_OneItemPerLine(t *testing.T) {
+	out := printResult(t, FormatNDJSON, testResult())
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || lines[0] != `{"id":"1","title":"a, b"}` {
+		t.Errorf("ndjson lines = %q", lines)
+	}
+}
+
+func TestYAMLPrinter_UsesJSONFieldNames(t *testing.T) {
+	out := printResult(t, FormatYAML, Result{Value: item{ID: "7", Title: "t"}})
+	if !strings.Contains(out, "id: \"7\"") || !strings.Contains(out, "title: t") {
+		t.Errorf("yaml = %q", out)
+	}
+}
+
+func TestPrinters_MessageOnlyResult(t *testing.T) {
+	var nilItem *item
+	r := Result{Value: nilItem, Message: "Task updated"}
+	for _, f := range []Format{FormatTable, FormatJSON, FormatYAML, FormatNDJSON} {
+		if out := printResult(t, f, r); out != "Task updated\n" {
+			t.Errorf("%s: output = %q, want message", f, out)
+		}
+	}
+	if out := printResult(t, FormatJSON, Result{Value: item{ID: "1"}, Message: "Task created"}); !strings.Contains(out, `"id":"1"`) {
+		t.Errorf("json should print the value, got %q", out)
+	}
+}
+
+func TestParseFormat_TemplateAndJSONPath(t *testing.T) {
+	for _, s := range []string{"template={{.ID}}", "jsonpath={.content[*].id}"} {
+		if f, err := ParseFormat(s); err != nil || string(f) != s {
+			t.Errorf("ParseFormat(%q) = %q, %v", s, f, err)
+		}
+	}
+	for _, s := range []string{"template={{.ID", "jsonpath={.content[*]", "jsonpath={range .x}"} {
+		if _, err := ParseFormat(s); err == nil {
+			t.Errorf("ParseFormat(%q): expected parse error", s)
+		}
+	}
+}
+
+func TestTemplatePrinter_RunsPerItemOnTypedValues(t *testing.T) {
+	out := printResult(t, Format("template={{.ID}} {{.Title}}"), testResult())
+	if want := "1 a, b\n2 Задача\n"; out != want {
+		t.Errorf("template = %q, want %q", out, want)
+	}
+	out = printResult(t, Format("template={{.ID}}"), Result{Value: &item{ID: "7"}})
+	if out != "7\n" {
+		t.Errorf("template on single value = %q", out)
+	}
+}
+
+func TestJSONPathPrinter_UsesJSONFieldNames(t *testing.T) {
+	tests := []struct {
+		expr, want string
+	}{
+		{"{.content[*].id}", "1 2\n"},
+		{"{.content[0].title}", "a, b\n"},
+		{"{.content[-1].id}", "2\n"},
+		{"{.content[0:1].id}", "1\n"},
+		{"{..id}", "1 2\n"},
+		{"{.content[0]['title']}", "a, b\n"},
+		{`{range .content[*]}{.id}{"\t"}{.title}{"\n"}{end}`, "1\ta, b\n2\tЗадача\n"},
+		{"{.missing}", "\n"},
+		{"{.content[0]}", `{"id":"1","title":"a, b"}` + "\n"},
+	}
+	for _, tt := range tests {
+		if out := printResult(t, Format("jsonpath="+tt.expr), testResult()); out != tt.want {
+			t.Errorf("jsonpath %s = %q, want %q", tt.expr, out, tt.want)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a parsed kubectl-style JSONPath template, e.g.
+// `{.content[*].id}` or `{range .content[*]}{.id}{"\t"}{.title}{"\n"}{end}`.
+//
+// Supported: text outside braces, string literals ({"\n"}), {range path}…{end},
+// and paths made of .field, ['field'], [n] (negative counts from the end),
+// [start:end], [*], .* and ..field (recursive descent). $ is the root and @ or
+// a leading . the current value. Several matches of one path are printed
+// separated by spaces; missing keys print nothing.
+type JSONPath struct {
+	nodes []jpNode
+}
+
+type jpNode struct {
+	text  string   // literal text (when path is nil and body is nil)
+	path  []jpStep // value to print, or the range source
+	body  []jpNode // range body
+	isRng bool
+}
+
+type jpStepKind int
+
+const (
+	stepField jpStepKind = iota
+	stepIndex
+	stepSlice
+	stepWildcard
+	stepRecursive
+	stepRoot
+)
+
+type jpStep struct {
+	kind       jpStepKind
+	name       string
+	index      int
+	start, end *int
+}
+
+// ParseJSONPath parses a JSONPath template.
+func ParseJSONPath(tmpl string) (*JSONPath, error) {
+	nodes, rest, err := parseJPNodes(tmpl, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("jsonpath: unexpected {end}")
+	}
+	return &JSONPath{nodes: nodes}, nil
+}
+
+// parseJPNodes parses until the end of s or, inRange, until {end}.
+// It returns the text after {end}.
+func parseJPNodes(s string, inRange bool) ([]jpNode, string, error) {
+	var nodes []jpNode
+	for s != "" {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			nodes = append(nodes, jpNode{text: s})
+			s = ""
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jpNode{text: s[:open]})
+		}
+		closing := matchBrace(s, open)
+		if closing < 0 {
+			return nil, "", fmt.Errorf("jsonpath: unclosed { in %q", s)
+		}
+		expr := strings.TrimSpace(s[open+1 : closing])
+		s = s[closing+1:]
+		switch {
+		case expr == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("jsonpath: {end} without {range}")
+			}
+			return nodes, s + "\x00", nil
+		case strings.HasPrefix(expr, "range "):
+			path, err := parseJPPath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+			body, rest, err := parseJPNodes(s, true)
+			if err != nil {
+				return nil, "", err
+			}
+			if !strings.HasSuffix(rest, "\x00") {
+				return nil, "", fmt.Errorf("jsonpath: {range} without {end}")
+			}
+			s = strings.TrimSuffix(rest, "\x00")
+			nodes = append(nodes, jpNode{path: path, body: body, isRng: true})
+		case strings.HasPrefix(expr, `"`):
+			text, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, "", fmt.Errorf("jsonpath: bad string literal %s", expr)
+			}
+			nodes = append(nodes, jpNode{text: text})
+		default:
+			path, err := parseJPPath(expr)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jpNode{path: path})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("jsonpath: {range} without {end}")
+	}
+	return nodes, "", nil
+}
+
+// matchBrace returns the index of the } closing the { at open, skipping quoted strings.
+func matchBrace(s string, open int) int {
+	inQuote := byte(0)
+	for i := open + 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inQuote != 0:
+			if c == '\\' {
+				i++
+			} else if c == inQuote {
+				inQuote = 0
+			}
+		case c == '"' || c == '\'':
+			inQuote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseJPPath(expr string) ([]jpStep, error) {
+	var steps []jpStep
+	s := expr
+	switch {
+	case strings.HasPrefix(s, "$"):
+		steps = append(steps, jpStep{kind: stepRoot})
+		s = s[1:]
+	case strings.HasPrefix(s, "@"):
+		s = s[1:]
+	}
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := readJPName(s[2:])
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath: expected field after .. in %q", expr)
+			}
+			steps = append(steps, jpStep{kind: stepRecursive, name: name})
+			s = rest
+		case strings.HasPrefix(s, ".*"):
+			steps = append(steps, jpStep{kind: stepWildcard})
+			s = s[2:]
+		case strings.HasPrefix(s, "."):
+			name, rest := readJPName(s[1:])
+			if name != "" {
+				steps = append(steps, jpStep{kind: stepField, name: name})
+			}
+			s = rest
+		case strings.HasPrefix(s, "["):
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath: unclosed [ in %q", expr)
+			}
+			step, err := parseJPBracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath: %w in %q", err, expr)
+			}
+			steps = append(steps, step)
+			s = s[end+1:]
+		default:
+			return nil, fmt.Errorf("jsonpath: unexpected %q in %q", s, expr)
+		}
+	}
+	return steps, nil
+}
+
+func readJPName(s string) (name, rest string) {
+	i := 0
+	for i < len(s) && s[i] != '.' && s[i] != '[' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func parseJPBracket(b string) (jpStep, error) {
+	switch {
+	case b == "*":
+		return jpStep{kind: stepWildcard}, nil
+	case len(b) >= 2 && (b[0] == '\'' || b[0] == '"') && b[len(b)-1] == b[0]:
+		return jpStep{kind: stepField, name: b[1 : len(b)-1]}, nil
+	case strings.Contains(b, ":"):
+		parts := strings.SplitN(b, ":", 2)
+		step := jpStep{kind: stepSlice}
+		for i, p := range parts {
+			p = strings.TrimSpace(p)
+			if p == "" {
+				continue
+			}
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				return jpStep{}, fmt.Errorf("bad slice [%s]", b)
+			}
+			if i == 0 {
+				step.start = &n
+			} else {
+				step.end = &n
+			}
+		}
+		return step, nil
+	default:
+		n, err := strconv.Atoi(b)
+		if err != nil {
+			return jpStep{}, fmt.Errorf("bad index [%s]", b)
+		}
+		return jpStep{kind: stepIndex, index: n}, nil
+	}
+}
+
+// Execute writes the template applied to data (decoded JSON: maps, slices, scalars).
+func (jp *JSONPath) Execute(w io.Writer, data interface{}) error {
+	var b strings.Builder
+	if err := execJPNodes(&b, jp.nodes, data, data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func execJPNodes(b *strings.Builder, nodes []jpNode, root, cur interface{}) error {
+	for _, n := range nodes {
+		switch {
+		case n.isRng:
+			for _, v := range evalJPPath(n.path, root, cur) {
+				if err := execJPNodes(b, n.body, root, v); err != nil {
+					return err
+				}
+			}
+		case n.path != nil:
+			for i, v := range evalJPPath(n.path, root, cur) {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				s, err := formatJPValue(v)
+				if err != nil {
+					return err
+				}
+				b.WriteString(s)
+			}
+		default:
+			b.WriteString(n.text)
+		}
+	}
+	return nil
+}
+
+func evalJPPath(steps []jpStep, root, cur interface{}) []interface{} {
+	vals := []interface{}{cur}
+	for _, st := range steps {
+		var next []interface{}
+		for _, v := range vals {
+			next = append(next, applyJPStep(st, root, v)...)
+		}
+		vals = next
+	}
+	return vals
+}
+
+func applyJPStep(st jpStep, root, v interface{}) []interface{} {
+	switch st.kind {
+	case stepRoot:
+		return []interface{}{root}
+	case stepField:
+		if m, ok := v.(map[string]interface{}); ok {
+			if x, ok := m[st.name]; ok {
+				return []interface{}{x}
+			}
+		}
+	case stepIndex:
+		if a, ok := v.([]interface{}); ok {
+			i := st.index
+			if i < 0 {
+				i += len(a)
+			}
+			if i >= 0 && i < len(a) {
+				return []interface{}{a[i]}
+			}
+		}
+	case stepSlice:
+		if a, ok := v.([]interface{}); ok {
+			start, end := 0, len(a)
+			if st.start != nil {
+				start = clampIndex(*st.start, len(a))
+			}
+			if st.end != nil {
+				end = clampIndex(*st.end, len(a))
+			}
+			if start < end {
+				return a[start:end]
+			}
+		}
+	case stepWildcard:
+		switch x := v.(type) {
+		case []interface{}:
+			return x
+		case map[string]interface{}:
+			out := make([]interface{}, 0, len(x))
+			for _, k := range sortedKeys(x) {
+				out = append(out, x[k])
+			}
+			return out
+		}
+	case stepRecursive:
+		var out []interface{}
+		collectJPRecursive(st.name, v, &out)
+		return out
+	}
+	return nil
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+func collectJPRecursive(name string, v interface{}, out *[]interface{}) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if f, ok := x[name]; ok {
+			*out = append(*out, f)
+		}
+		for _, k := range sortedKeys(x) {
+			collectJPRecursive(name, x[k], out)
+		}
+	case []interface{}:
+		for _, e := range x {
+			collectJPRecursive(name, e, out)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatJPValue prints scalars as text and objects/arrays as JSON.
+func formatJPValue(v interface{}) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -1,53 +1,131 @@
 package output
 
 import (
+	"bufio"
 	"encoding/json"
-	"fmt"
 	"io"
 	"strings"
+
+	"github.com/mattn/go-runewidth"
 )
 
+// TableOptions control the layout of tables. Widths are measured in terminal
+// cells, so Cyrillic letters count as one and CJK characters as two.
+type TableOptions struct {
+	// NoHeaders omits the header and separator lines.
+	NoHeaders bool
+	// MaxWidth is the line width to fit; 0 means no limit. The widest
+	// columns are narrowed first and their cells cut with "…".
+	MaxWidth int
+	// Wrap continues long cells on the following lines instead of cutting them.
+	Wrap bool
+}
+
+// minColumnWidth is how narrow fitting to MaxWidth may make a column.
+const minColumnWidth = 6
+
+const ellipsis = "…"
+
+var flattenCell = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ")
+
 // PrintTable writes a table with headers and rows to w.
 // Each row must have the same number of columns as headers.
 func PrintTable(w io.Writer, headers []string, rows [][]string) error {
+	return TableOptions{}.Print(w, headers, rows)
+}
+
+// Print writes a table with headers and rows to w laid out by o.
+func (o TableOptions) Print(w io.Writer, headers []string, rows [][]string) error {
 	if len(headers) == 0 {
 		return nil
 	}
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(headers))
+		for i := range headers {
+			if i < len(row) {
+				// Multi-line values (descriptions) would break the row layout.
+				cells[r][i] = flattenCell.Replace(row[i])
+			}
+		}
+	}
 	widths := make([]int, len(headers))
-	for i, h := range headers {
-		if len(h) > widths[i] {
-			widths[i] = len(h)
+	if !o.NoHeaders {
+		for i, h := range headers {
+			widths[i] = runewidth.StringWidth(h)
 		}
 	}
-	for _, row := range rows {
+	for _, row := range cells {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
-			}
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
 		}
 	}
-	format := strings.TrimSuffix(strings.Repeat("%-*s  ", len(headers)), "  ") + "\n"
-	args := make([]interface{}, 0, len(headers)*2)
-	for i := range headers {
-		args = append(args, widths[i], headers[i])
+	if o.MaxWidth > 0 {
+		fitWidths(widths, o.MaxWidth-2*(len(widths)-1))
+	}
+
+	bw := bufio.NewWriter(w)
+	if !o.NoHeaders {
+		o.writeRow(bw, headers, widths)
+		bw.WriteString(strings.Repeat("-", sum(widths)+2*len(widths)-2) + "\n")
 	}
-	if _, err := fmt.Fprintf(w, format, args...); err != nil {
-		return err
+	for _, row := range cells {
+		o.writeRow(bw, row, widths)
 	}
-	sep := strings.Repeat("-", sum(widths)+2*len(headers)-2) + "\n"
-	if _, err := io.WriteString(w, sep); err != nil {
-		return err
+	return bw.Flush()
+}
+
+// writeRow writes one table row; wrapped cells make it several lines high.
+func (o TableOptions) writeRow(w *bufio.Writer, row []string, widths []int) {
+	lines := make([][]string, len(widths))
+	height := 1
+	for i := range widths {
+		cell := ""
+		if i < len(row) {
+			cell = row[i]
+		}
+		if runewidth.StringWidth(cell) <= widths[i] {
+			lines[i] = []string{cell}
+			continue
+		}
+		if o.Wrap {
+			lines[i] = strings.Split(runewidth.Wrap(cell, widths[i]), "\n")
+			height = max(height, len(lines[i]))
+		} else {
+			lines[i] = []string{runewidth.Truncate(cell, widths[i], ellipsis)}
+		}
 	}
-	for _, row := range rows {
-		args = args[:0]
-		for i := 0; i < len(headers) && i < len(row); i++ {
-			args = append(args, widths[i], row[i])
+	for l := 0; l < height; l++ {
+		var b strings.Builder
+		for i, width := range widths {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			part := ""
+			if l < len(lines[i]) {
+				part = lines[i][l]
+			}
+			b.WriteString(runewidth.FillRight(part, width))
+		}
+		w.WriteString(strings.TrimRight(b.String(), " ") + "\n")
+	}
+}
+
+// fitWidths narrows the widest columns until their sum fits avail or every
+// column is down to minColumnWidth (or its natural width, if smaller).
+func fitWidths(widths []int, avail int) {
+	for sum(widths) > avail {
+		widest := -1
+		for i, wd := range widths {
+			if wd > minColumnWidth && (widest < 0 || wd > widths[widest]) {
+				widest = i
+			}
 		}
-		if _, err := fmt.Fprintf(w, format, args...); err != nil {
-			return err
+		if widest < 0 {
+			return
 		}
+		widths[widest]--
 	}
-	return nil
 }
 
 func sum(w []int) int {
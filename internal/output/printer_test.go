@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/mattn/go-runewidth"
 )
 
 func TestPrintTable_HeadersAndRows_WritesTable(t *testing.T) {
@@ -42,3 +44,46 @@ func TestPrintJSON_ValidStruct_WritesValidJSON(t *testing.T) {
 		t.Errorf("decoded = %v", decoded)
 	}
 }
+
+func TestPrintTable_Cyrillic_AlignsByDisplayWidth(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintTable(&buf, []string{"Title", "ID"}, [][]string{{"Задача", "1"}, {"abc", "2"}, {"漢字", "3"}}); err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	// "ID" must start at the same terminal column on every line.
+	want := strings.Index(lines[0], "ID")
+	for _, l := range lines[2:5] {
+		prefix := l[:strings.LastIndex(l, " ")+1]
+		if got := runewidth.StringWidth(prefix); got != want {
+			t.Errorf("line %q: second column at %d, want %d", l, got, want)
+		}
+	}
+}
+
+func TestTableOptions_MaxWidth_TruncatesWithEllipsis(t *testing.T) {
+	var buf bytes.Buffer
+	opts := TableOptions{MaxWidth: 20}
+	if err := opts.Print(&buf, []string{"ID", "Title"}, [][]string{{"1", "Очень длинное название задачи"}}); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if runewidth.StringWidth(l) > 20 {
+			t.Errorf("line %q is wider than 20", l)
+		}
+	}
+	if !strings.Contains(buf.String(), "Очень длинное н…") {
+		t.Errorf("want truncated title with ellipsis:\n%s", buf.String())
+	}
+}
+
+func TestTableOptions_Wrap_ContinuesOnNextLines(t *testing.T) {
+	var buf bytes.Buffer
+	opts := TableOptions{MaxWidth: 12, Wrap: true, NoHeaders: true}
+	if err := opts.Print(&buf, []string{"ID", "Title"}, [][]string{{"1", "абвгдеёжзий"}}); err != nil {
+		t.Fatalf("Print: %v", err)
+	}
+	if want := "1  абвгдеёжз\n   ий\n"; buf.String() != want {
+		t.Errorf("wrapped = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,366 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// View selects and orders table columns (--columns) and rows (--sort-by).
+//
+// Names refer to a column of the command's table (by header or Field,
+// case-insensitive) or to any JSON field of the row DTO; dotted names reach
+// nested fields (timeTracking.plan). Table columns sort by their displayed
+// text, so a column showing names sorts by name. A leading "-" in SortBy
+// sorts in descending order.
+type View struct {
+	Columns []string
+	SortBy  string
+}
+
+// ParseColumns splits a comma-separated --columns value.
+func ParseColumns(s string) []string {
+	var cols []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// IsZero reports whether v leaves results unchanged.
+func (v View) IsZero() bool {
+	return len(v.Columns) == 0 && v.SortBy == ""
+}
+
+// viewRow is one table row with the list item it was rendered from.
+type viewRow struct {
+	item    interface{} // typed DTO, nil without Items
+	generic interface{} // JSON form of item
+	cells   []string
+}
+
+// Apply returns r with Table and Items reshaped by v. Value, the full API
+// response printed by json and yaml, is left as is. Results without a table
+// (single objects, messages) are returned unchanged.
+func (v View) Apply(r Result) (Result, error) {
+	if v.IsZero() || r.Table == nil {
+		return r, nil
+	}
+	rows, err := viewRows(r)
+	if err != nil {
+		return r, err
+	}
+	fields := itemFields(r.Items)
+
+	if v.SortBy != "" {
+		name, desc := strings.CutPrefix(v.SortBy, "-")
+		col, ok := findColumn(r.Table, name)
+		if !ok && !hasField(fields, name) {
+			return r, unknownColumnError("sort field", name, r.Table, fields)
+		}
+		keys := make([]interface{}, len(rows))
+		for i, row := range rows {
+			if ok {
+				keys[i] = cellValue(cellAt(row.cells, col))
+			} else {
+				keys[i] = sortValue(lookupField(row.generic, name), name)
+			}
+		}
+		idx := make([]int, len(rows))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(a, b int) bool {
+			ka, kb := keys[idx[a]], keys[idx[b]]
+			if ka == nil || kb == nil {
+				return kb == nil && ka != nil // missing values last in both orders
+			}
+			c := compareValues(ka, kb)
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		})
+		sorted := make([]viewRow, len(rows))
+		for i, j := range idx {
+			sorted[i] = rows[j]
+		}
+		rows = sorted
+	}
+
+	table := &Table{Columns: r.Table.Columns}
+	if len(v.Columns) > 0 {
+		table.Columns = make([]Column, len(v.Columns))
+		for i, name := range v.Columns {
+			if c, ok := findColumn(r.Table, name); ok {
+				table.Columns[i] = Column{Header: r.Table.Columns[c].Header, Field: r.Table.Columns[c].Field}
+				continue
+			}
+			if !hasField(fields, name) {
+				return r, unknownColumnError("column", name, r.Table, fields)
+			}
+			table.Columns[i] = Column{Header: name, Field: name}
+		}
+	}
+	for _, row := range rows {
+		if len(v.Columns) == 0 {
+			table.Rows = append(table.Rows, row.cells)
+			continue
+		}
+		cells := make([]string, len(v.Columns))
+		for i, name := range v.Columns {
+			if c, ok := findColumn(r.Table, name); ok {
+				cells[i] = cellAt(row.cells, c)
+			} else {
+				cells[i] = formatCell(lookupField(row.generic, name))
+			}
+		}
+		table.Rows = append(table.Rows, cells)
+	}
+	r.Table = table
+
+	if r.Items != nil && v.SortBy != "" {
+		items := make([]interface{}, len(rows))
+		for i, row := range rows {
+			items[i] = row.item
+		}
+		r.Items = items
+	}
+	return r, nil
+}
+
+// viewRows pairs the table rows with Items; they must be the same length.
+func viewRows(r Result) ([]viewRow, error) {
+	rows := make([]viewRow, len(r.Table.Rows))
+	for i, cells := range r.Table.Rows {
+		rows[i].cells = cells
+	}
+	if r.Items == nil {
+		return rows, nil
+	}
+	var items []interface{}
+	_ = eachItem(r.Items, func(item interface{}) error {
+		items = append(items, item)
+		return nil
+	})
+	if len(items) != len(rows) {
+		return rows, nil
+	}
+	for i, item := range items {
+		g, err := toGeneric(item)
+		if err != nil {
+			return nil, err
+		}
+		rows[i].item, rows[i].generic = item, g
+	}
+	return rows, nil
+}
+
+// findColumn returns the index of the column whose Field or Header is name.
+func findColumn(t *Table, name string) (int, bool) {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Field, name) || strings.EqualFold(c.Header, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func cellAt(cells []string, i int) string {
+	if i < len(cells) {
+		return cells[i]
+	}
+	return ""
+}
+
+// itemFields returns the JSON field names of the Items element type,
+// or nil if it is not a struct.
+func itemFields(items interface{}) []string {
+	if items == nil {
+		return nil
+	}
+	t := reflect.TypeOf(items)
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// hasField reports whether the first segment of a dotted name is one of fields.
+func hasField(fields []string, name string) bool {
+	head, _, _ := strings.Cut(name, ".")
+	for _, f := range fields {
+		if strings.EqualFold(f, head) {
+			return true
+		}
+	}
+	return false
+}
+
+func unknownColumnError(what, name string, t *Table, fields []string) error {
+	var avail []string
+	seen := map[string]bool{}
+	add := func(s string) {
+		if s != "" && !seen[strings.ToLower(s)] {
+			seen[strings.ToLower(s)] = true
+			avail = append(avail, s)
+		}
+	}
+	for _, c := range t.Columns {
+		if c.Field != "" {
+			add(c.Field)
+		} else {
+			add(columnName(c.Header))
+		}
+	}
+	for _, f := range fields {
+		add(f)
+	}
+	return fmt.Errorf("unknown %s %q (available: %s)", what, name, strings.Join(avail, ", "))
+}
+
+// columnName spells a header the way JSON fields are: "ID" -> "id",
+// "ColumnId" -> "columnId".
+func columnName(header string) string {
+	if header == strings.ToUpper(header) {
+		return strings.ToLower(header)
+	}
+	r := []rune(header)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// lookupField follows a dotted, case-insensitive path through JSON objects.
+func lookupField(v interface{}, name string) interface{} {
+	for _, part := range strings.Split(name, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		next, found := m[part]
+		if !found {
+			for k, x := range m {
+				if strings.EqualFold(k, part) {
+					next, found = x, true
+					break
+				}
+			}
+		}
+		if !found {
+			return nil
+		}
+		v = next
+	}
+	return v
+}
+
+// cellValue returns the sort key of a displayed cell: nil when empty, a number
+// when it is one, else the text. Dates are shown as "2006-01-02 15:04", which
+// sorts as text.
+func cellValue(cell string) interface{} {
+	if cell == "" {
+		return nil
+	}
+	if f, err := strconv.ParseFloat(cell, 64); err == nil {
+		return f
+	}
+	return cell
+}
+
+// sortValue returns the value to sort by. Objects that wrap a field of the
+// same name (a task's deadline.deadline) sort by that field.
+func sortValue(v interface{}, name string) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		last := name[strings.LastIndex(name, ".")+1:]
+		if inner := lookupField(m, last); inner != nil {
+			return inner
+		}
+	}
+	return v
+}
+
+// compareValues orders numbers numerically, strings case-insensitively
+// and false before true.
+func compareValues(a, b interface{}) int {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(strings.ToLower(formatCell(a)), strings.ToLower(formatCell(b)))
+}
+
+// formatCell renders a JSON value for a table cell: scalars as text,
+// arrays of scalars comma-separated, anything else as compact JSON.
+func formatCell(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		if x {
+			return "yes"
+		}
+		return "no"
+	case []interface{}:
+		parts := make([]string, 0, len(x))
+		for _, e := range x {
+			switch e.(type) {
+			case map[string]interface{}, []interface{}:
+				data, _ := json.Marshal(x)
+				return string(data)
+			}
+			parts = append(parts, formatCell(e))
+		}
+		return strings.Join(parts, ",")
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
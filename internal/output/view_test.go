@@ -0,0 +1,92 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+type task struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Deadline *deadline      `json:"deadline,omitempty"`
+	Tracking map[string]int `json:"timeTracking,omitempty"`
+}
+
+type deadline struct {
+	Deadline float64 `json:"deadline"`
+}
+
+func taskResult() Result {
+	items := []task{
+		{ID: "1", Title: "b", Deadline: &deadline{Deadline: 300}, Tracking: map[string]int{"plan": 2}},
+		{ID: "2", Title: "A"},
+		{ID: "3", Title: "c", Deadline: &deadline{Deadline: 100}},
+	}
+	t := &Table{Columns: []Column{{Header: "ID"}, {Header: "Title"}, {Header: "Due", Wide: true, Field: "deadline"}}}
+	for _, it := range items {
+		due := ""
+		if it.Deadline != nil {
+			due = "at " + formatCell(it.Deadline.Deadline)
+		}
+		t.Rows = append(t.Rows, []string{it.ID, it.Title, due})
+	}
+	return Result{Value: items, Items: items, Table: t}
+}
+
+func TestView_Columns_UsesTableCellsAndRowFields(t *testing.T) {
+	r, err := View{Columns: []string{"id", "deadline", "timeTracking.plan"}}.Apply(taskResult())
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	out := printResult(t, FormatCSV, r)
+	if want := "ID,Due,timeTracking.plan\n1,at 300,2\n2,,\n3,at 100,\n"; out != want {
+		t.Errorf("csv = %q, want %q", out, want)
+	}
+	// Selected wide columns are shown in plain table mode.
+	if out := printResult(t, FormatTable, r); !strings.Contains(out, "Due") {
+		t.Errorf("table should show requested wide column:\n%s", out)
+	}
+}
+
+func TestView_SortBy_FieldValuesWithMissingLast(t *testing.T) {
+	tests := []struct {
+		sortBy, want string
+	}{
+		{"deadline", "3,1,2"},
+		{"-deadline", "1,3,2"},
+		{"title", "2,1,3"},
+		{"-id", "3,2,1"},
+	}
+	for _, tt := range tests {
+		r, err := View{SortBy: tt.sortBy}.Apply(taskResult())
+		if err != nil {
+			t.Fatalf("Apply(%s): %v", tt.sortBy, err)
+		}
+		var ids []string
+		for _, row := range r.Table.Rows {
+			ids = append(ids, row[0])
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("--sort-by %s: rows %s, want %s", tt.sortBy, got, tt.want)
+		}
+		if first := r.Items.([]interface{})[0].(task).ID; first != ids[0] {
+			t.Errorf("--sort-by %s: items not reordered (first %s)", tt.sortBy, first)
+		}
+	}
+}
+
+func TestView_UnknownName_ListsAvailable(t *testing.T) {
+	_, err := View{Columns: []string{"nope"}}.Apply(taskResult())
+	if err == nil || !strings.Contains(err.Error(), "timeTracking") {
+		t.Fatalf("err = %v, want unknown column listing fields", err)
+	}
+	if _, err := (View{SortBy: "nope"}).Apply(taskResult()); err == nil {
+		t.Fatal("expected error for unknown sort field")
+	}
+}
+
+func TestParseColumns_TrimsAndSkipsEmpty(t *testing.T) {
+	if got := ParseColumns(" id, title,,deadline "); strings.Join(got, "|") != "id|title|deadline" {
+		t.Errorf("ParseColumns = %q", got)
+	}
+}
@@ -0,0 +1,166 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+)
+
+// FileStore stores secrets as a JSON object in an age file encrypted
+// with a passphrase (scrypt). Every Set and Delete rewrites the whole file.
+type FileStore struct {
+	path       string
+	passphrase func() (string, error)
+	pass       string
+	workFactor int // scrypt log2 work factor; 0 means the age default
+}
+
+// NewFileStore returns a Store backed by the encrypted file at path.
+// passphrase is called the first time the file is read or written.
+func NewFileStore(path string, passphrase func() (string, error)) *FileStore {
+	return &FileStore{path: path, passphrase: passphrase}
+}
+
+// Get returns the secret for account.
+func (s *FileStore) Get(account string) (string, error) {
+	m, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := m[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+// Set stores the secret for account, replacing any previous value.
+func (s *FileStore) Set(account, secret string) error {
+	m, err := s.load()
+	if err != nil {
+		return err
+	}
+	m[account] = secret
+	return s.save(m)
+}
+
+// Delete removes the secret for account.
+func (s *FileStore) Delete(account string) error {
+	m, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := m[account]; !ok {
+		return ErrNotFound
+	}
+	delete(m, account)
+	return s.save(m)
+}
+
+func (s *FileStore) getPassphrase() (string, error) {
+	if s.pass != "" {
+		return s.pass, nil
+	}
+	pass, err := s.passphrase()
+	if err != nil {
+		return "", err
+	}
+	if pass == "" {
+		return "", fmt.Errorf("empty passphrase for %s", s.path)
+	}
+	s.pass = pass
+	return pass, nil
+}
+
+// load decrypts the file. A missing file is an empty store.
+func (s *FileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+	pass, err := s.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	id, err := age.NewScryptIdentity(pass)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials: %w", err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), id)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s (wrong passphrase?): %w", s.path, err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", s.path, err)
+	}
+	m := map[string]string{}
+	if err := json.Unmarshal(plain, &m); err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+	return m, nil
+}
+
+// save encrypts m and atomically replaces the file (mode 0600).
+func (s *FileStore) save(m map[string]string) error {
+	pass, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+	rcpt, err := age.NewScryptRecipient(pass)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+	if s.workFactor > 0 {
+		rcpt.SetWorkFactor(s.workFactor)
+	}
+	plain, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encode credentials: %w", err)
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, rcpt)
+	if err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+	if _, err := w.Write(plain); err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("encrypt credentials: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create credentials dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeyringStore stores secrets in the OS keyring under one service name.
+type KeyringStore struct {
+	service string
+}
+
+// NewKeyringStore returns a keyring-backed Store for service.
+func NewKeyringStore(service string) *KeyringStore {
+	return &KeyringStore{service: service}
+}
+
+// Get returns the secret for account.
+func (s *KeyringStore) Get(account string) (string, error) {
+	v, err := keyring.Get(s.service, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("keyring: %w", err)
+	}
+	return v, nil
+}
+
+// Set stores the secret for account, replacing any previous value.
+func (s *KeyringStore) Set(account, secret string) error {
+	if err := keyring.Set(s.service, account, secret); err != nil {
+		return fmt.Errorf("keyring: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the secret for account.
+func (s *KeyringStore) Delete(account string) error {
+	err := keyring.Delete(s.service, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("keyring: %w", err)
+	}
+	return nil
+}
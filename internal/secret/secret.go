@@ -0,0 +1,64 @@
+// Package secret keeps API keys out of the plaintext config file.
+//
+// A Store maps an account (a config profile name and its base URL) to its
+// API key. Two backends are available: the OS keyring (Secret Service over
+// D-Bus on Linux, Keychain on macOS, Credential Manager on Windows) and an
+// age-encrypted file protected by a passphrase, which works on headless hosts.
+package secret
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Backend names, as used by the credential_store config key.
+const (
+	// Config keeps api_key in config.yaml (the default, no Store).
+	Config = "config"
+	// Keyring uses the OS keyring.
+	Keyring = "keyring"
+	// File uses an age passphrase-encrypted file.
+	File = "file"
+)
+
+// Service is the keyring service name API keys are stored under.
+const Service = "yougile-cli"
+
+// ErrNotFound is returned by Get and Delete when no secret is stored for the account.
+var ErrNotFound = errors.New("secret not found")
+
+// Store holds one secret per account.
+type Store interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// Options configure Open.
+type Options struct {
+	// FilePath is the encrypted file used by the File backend.
+	FilePath string
+	// Passphrase returns the File backend passphrase. It is called at most once.
+	Passphrase func() (string, error)
+}
+
+// Open returns the Store for backend. It returns a nil Store for Config
+// (and ""), meaning keys stay in the config file.
+func Open(backend string, opts Options) (Store, error) {
+	switch backend {
+	case "", Config:
+		return nil, nil
+	case Keyring:
+		return NewKeyringStore(Service), nil
+	case File:
+		if opts.FilePath == "" {
+			return nil, fmt.Errorf("credential store %q: file path not set", File)
+		}
+		if opts.Passphrase == nil {
+			return nil, fmt.Errorf("credential store %q: no passphrase source", File)
+		}
+		return NewFileStore(opts.FilePath, opts.Passphrase), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %q (want %s, %s or %s)", backend, Config, Keyring, File)
+	}
+}
@@ -0,0 +1,106 @@
+package secret
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func newTestFileStore(path, pass string) *FileStore {
+	s := NewFileStore(path, func() (string, error) { return pass, nil })
+	s.workFactor = 10 // keep scrypt fast in tests
+	return s
+}
+
+func TestFileStore_SetGetDelete_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.age")
+	s := newTestFileStore(path, "pw")
+
+	if _, err := s.Get("default"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get on missing file: err = %v, want ErrNotFound", err)
+	}
+	if err := s.Set("default", "key-1"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := s.Set("work", "key-2"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// A fresh store must decrypt what the first one wrote.
+	got, err := newTestFileStore(path, "pw").Get("work")
+	if err != nil || got != "key-2" {
+		t.Fatalf("Get(work) = %q, %v; want key-2", got, err)
+	}
+	if err := s.Delete("work"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get("work"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStore_FileIsEncryptedAndPrivate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.age")
+	if err := newTestFileStore(path, "pw").Set("default", "plain-secret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "plain-secret") {
+		t.Error("file contains the secret in clear text")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("mode = %o, want 600", perm)
+	}
+}
+
+func TestFileStore_WrongPassphrase_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.age")
+	if err := newTestFileStore(path, "right").Set("default", "k"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	_, err := newTestFileStore(path, "wrong").Get("default")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want decrypt error", err)
+	}
+}
+
+func TestKeyringStore_MapsNotFound(t *testing.T) {
+	keyring.MockInit()
+	s := NewKeyringStore(Service)
+
+	if _, err := s.Get("default"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get: err = %v, want ErrNotFound", err)
+	}
+	if err := s.Set("default", "k"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, err := s.Get("default"); err != nil || got != "k" {
+		t.Errorf("Get = %q, %v; want k", got, err)
+	}
+	if err := s.Delete("default"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete("default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOpen_UnknownBackend_Errors(t *testing.T) {
+	if _, err := Open("vault", Options{}); err == nil {
+		t.Fatal("expected error for unknown backend")
+	}
+	if s, err := Open(Config, Options{}); s != nil || err != nil {
+		t.Errorf("Open(config) = %v, %v; want nil store", s, err)
+	}
+}
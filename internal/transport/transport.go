@@ -0,0 +1,182 @@
+// Package transport provides the HTTP round tripper used for YouGile API calls:
+// client-side rate limiting plus retries on 429 and 5xx responses.
+package transport
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// API limits from docs/api.json: at most 50 requests per minute per company.
+const (
+	DefaultRequestsPerMinute = 50
+	DefaultBurst             = 10
+	DefaultMaxRetries        = 4
+	DefaultBaseDelay         = time.Second
+	DefaultMaxDelay          = 60 * time.Second
+)
+
+// Transport is an http.RoundTripper that throttles requests with a token bucket
+// and retries rate-limited (429) and server error (5xx) responses with backoff.
+// Retry-After is honored when present. 5xx responses are retried only for
+// idempotent methods, since a failed POST may still have created the object.
+type Transport struct {
+	// Base performs the actual requests. http.DefaultTransport if nil.
+	Base http.RoundTripper
+	// Limiter throttles outgoing requests, including retries. No throttling if nil.
+	Limiter *Limiter
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles on each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps both the computed backoff and Retry-After.
+	MaxDelay time.Duration
+}
+
+// New returns a Transport over base with the default limits. The bucket
+// refills at DefaultRequestsPerMinute-DefaultBurst per minute, so a full burst
+// plus a minute of refill stays within DefaultRequestsPerMinute.
+func New(base http.RoundTripper) *Transport {
+	return &Transport{
+		Base:       base,
+		Limiter:    NewLimiter(DefaultRequestsPerMinute-DefaultBurst, DefaultBurst),
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultBaseDelay,
+		MaxDelay:   DefaultMaxDelay,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if t.Limiter != nil {
+			if err := t.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := t.base().RoundTrip(attemptReq)
+		if err != nil {
+			return nil, err
+		}
+		if attempt >= t.MaxRetries || !shouldRetry(req, resp.StatusCode) {
+			return resp, nil
+		}
+		next, ok := rewind(req)
+		if !ok {
+			return resp, nil
+		}
+		delay := t.delay(attempt, resp)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		attemptReq = next
+	}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// delay returns how long to wait before retry number attempt+1.
+func (t *Transport) delay(attempt int, resp *http.Response) time.Duration {
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return t.capDelay(d)
+	}
+	d := t.BaseDelay << attempt
+	if d > 0 {
+		// Up to 50% jitter so parallel scripts don't retry in lockstep.
+		d += time.Duration(rand.Int64N(int64(d)/2 + 1))
+	}
+	return t.capDelay(d)
+}
+
+func (t *Transport) capDelay(d time.Duration) time.Duration {
+	if t.MaxDelay > 0 && d > t.MaxDelay {
+		return t.MaxDelay
+	}
+	return d
+}
+
+// shouldRetry reports whether a response with status code is worth retrying for req.
+func shouldRetry(req *http.Request, code int) bool {
+	if code == http.StatusTooManyRequests {
+		return true
+	}
+	if code >= 500 && code != http.StatusNotImplemented {
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewind returns a copy of req with a fresh body for another attempt.
+// ok is false if the body cannot be replayed.
+func rewind(req *http.Request) (*http.Request, bool) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next.Body = body
+	return next, true
+}
+
+// parseRetryAfter parses a Retry-After header: delay-seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		d := at.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTransport() *Transport {
+	return &Transport{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+}
+
+func TestTransport_429ThenOK_RetriesAndReplaysBody(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"title":"x"}` {
+			t.Errorf("attempt %d body = %q", calls, body)
+		}
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: newTestTransport()}
+	resp, err := c.Post(srv.URL, "application/json", strings.NewReader(`{"title":"x"}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status = %d, want 201", resp.StatusCode)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestTransport_5xxOnGet_RetriesUpToMax(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: newTestTransport()}
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", resp.StatusCode)
+	}
+	if calls != 4 {
+		t.Errorf("calls = %d, want 1 + 3 retries", calls)
+	}
+}
+
+func TestTransport_5xxOnPost_NotRetried(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: newTestTransport()}
+	resp, err := c.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	_ = resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestTransport_4xx_NotRetried(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := &http.Client{Transport: newTestTransport()}
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"Thu, 01 Jan 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"soon", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.in, now)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestLimiter_EmptyBucket_ReportsWaitUntilRefill(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(60, 2)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	if d := l.reserve(); d != 0 {
+		t.Fatalf("first reserve = %v, want 0", d)
+	}
+	if d := l.reserve(); d != 0 {
+		t.Fatalf("second reserve = %v, want 0", d)
+	}
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("third reserve = %v, want 1s at 60/min", d)
+	}
+	now = now.Add(time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("reserve after refill = %v, want 0", d)
+	}
+}
+
+func TestNew_Limiter_StaysWithinRequestsPerMinute(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(nil).Limiter
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	start, sent := now, 0
+	for now.Sub(start) < time.Minute {
+		if d := l.reserve(); d > 0 {
+			now = now.Add(d)
+			continue
+		}
+		sent++
+	}
+	if sent > DefaultRequestsPerMinute {
+		t.Errorf("%d requests in the first minute, want at most %d", sent, DefaultRequestsPerMinute)
+	}
+}
+
+func TestLimiter_Wait_ContextCanceled(t *testing.T) {
+	l := NewLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Error("expected context error when bucket is empty")
+	}
+}
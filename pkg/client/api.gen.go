@@ -915,7 +915,7 @@ type ChatMessageListDtoBase struct {
 // CheckList defines model for CheckList.
 type CheckList struct {
 	// Items Массив с чеклистами
-	Items CheckListItem `json:"items"`
+	Items []CheckListItem `json:"items"`
 
 	// Title Название списка чеклистов
 	Title string `json:"title"`
@@ -1720,7 +1720,7 @@ type Deadline struct {
 	BlockedPoints []string `json:"blockedPoints"`
 
 	// Deadline Timestamp дэдлайна
-	Deadline float32 `json:"deadline"`
+	Deadline int64 `json:"deadline"`
 
 	// History История изменений дедлайна
 	History *[]string `json:"history,omitempty"`
@@ -1729,7 +1729,7 @@ type Deadline struct {
 	Links []string `json:"links"`
 
 	// StartDate Timestamp начала задачи
-	StartDate *float32 `json:"startDate,omitempty"`
+	StartDate *int64 `json:"startDate,omitempty"`
 
 	// WithTime Отображать на стикере время, или только дату
 	WithTime *bool `json:"withTime,omitempty"`
@@ -2575,7 +2575,7 @@ type UpdateDeadline struct {
 	BlockedPoints []string `json:"blockedPoints"`
 
 	// Deadline Timestamp дэдлайна
-	Deadline *float32 `json:"deadline,omitempty"`
+	Deadline *int64 `json:"deadline,omitempty"`
 
 	// Deleted Открепить стикер от задачи (true)
 	Deleted *bool `json:"deleted,omitempty"`
@@ -2590,7 +2590,7 @@ type UpdateDeadline struct {
 	Links []string `json:"links"`
 
 	// StartDate Timestamp начала задачи
-	StartDate *float32 `json:"startDate,omitempty"`
+	StartDate *int64 `json:"startDate,omitempty"`
 
 	// WithTime Отображать на стикере время, или только дату
 	WithTime *bool `json:"withTime,omitempty"`